@@ -3,79 +3,712 @@ package main
 import (
 	"flag"
 	"fmt"
+	"net/http"
+	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"jira-helper/evidence"
 )
 
 // Constants for default values
 const (
 	DefaultJIRAIDRegex = "[A-Z]+-[0-9]+"
 	DefaultOutputFile  = "transformed_jira_data.json"
+	DefaultWatchPeriod = 2 * time.Second
+)
+
+// transitionsSinceLayout is the date layout accepted by --transitions-since
+const transitionsSinceLayout = "2006-01-02"
+
+// Output formats for --format
+const (
+	FormatJSON     = "json"
+	FormatMarkdown = "md"
+	FormatHTML     = "html"
+	FormatText     = "text"
+	FormatYAML     = "yaml"
 )
 
 // AppConfig holds all configuration for the application
 type AppConfig struct {
 	// JIRA Configuration
-	JIRAToken    string
-	JIRAURL      string
-	JIRAUsername string
-	JIRAIDRegex  string
+	JIRAToken      string
+	JIRAURL        string
+	JIRAUsername   string
+	JIRAIDRegex    string
+	ProjectRegexes map[string]string
+	BranchRules    []BranchRule
 
 	// Output Configuration
-	OutputFile string
+	OutputFile     string
+	MarkdownOutput string
+	SplitOutput    string
+	Formats        []string
+	Compact        bool
+	Fields         []string
+	PostURL        string
+	PostAuth       string
+	Append         bool
+	IfNewer        bool
+	Checksum       bool
+	TransitionsCSV string
+	MergeStrategy  string
+	DateFormat     string
+	SortBy         string
+	GroupBy        string
+	TOC            bool
+	Location       *time.Location
 
 	// Runtime Configuration
-	ExtractOnly    bool
-	ExtractFromGit bool
-	SingleCommit   bool
-	StartCommit    string
-	JIRAIDs        []string
+	ExtractOnly      bool
+	FailOnEmpty      bool
+	ExtractFromGit   bool
+	SingleCommit     bool
+	StartCommit      string
+	Watch            bool
+	WatchInterval    time.Duration
+	FromRef          string
+	ToRef            string
+	BaseBranch       string
+	CommitsFile      string
+	Path             string
+	FirstOnly        bool
+	IgnoreCase       bool
+	NoMerges         bool
+	IncludeNotes     bool
+	Strict           bool
+	MaxMessageLength int
+	WarnDuplicates   bool
+	WithCommitMeta   bool
+	GitPath          string
+	FallbackToRange  bool
+	ContinueOnError  bool
+	DefaultHead      bool
+	UseReflog        bool
+	IgnoreKeys       []string
+	JIRAIDs          []string
+	JQL              string
+	ScanFile         string
+	ListStatuses     string
+
+	// Fetch Configuration
+	KeyAliases          map[string]string
+	IncludeSprints      bool
+	IncludeUserIDs      bool
+	IncludeLinks        bool
+	IncludeSubtasks     bool
+	CollapseTransitions bool
+	Anonymize           bool
+	StatusMap           map[string]string
+	IncludeStatuses     []string
+	ExcludeStatuses     []string
+	IncludeTypes        []string
+	ExcludeTypes        []string
+	OnlyErrors          bool
+	TransitionsSince    time.Time
+	ProxyURL            string
+	InsecureSkipVerify  bool
+	CACertFile          string
+	UserAgent           string
+	Headers             http.Header
+	LinkTemplate        string
+	MaxIdleConns        int
+	IdleConnTimeout     time.Duration
+	NoKeepAlives        bool
+	Workers             int
+	DumpRawDir          string
+	MaxDescription      int
+
+	// Provenance, set by full mode from the git checkout
+	Source *evidence.SourceInfo
+
+	// Observability
+	Stats     *evidence.RunStats
+	Quiet     bool
+	Verbose   bool
+	LogFormat string
 }
 
 // FlagConfig holds command line flags
 type FlagConfig struct {
-	JIRAIDRegex      string
-	OutputFile       string
-	ExtractOnly      bool
-	ExtractFromGit   bool
-	CommitRange      bool
-	Help             bool
-	HelpLong         bool
-	GenerateMarkdown bool
-	MarkdownOutput   string
+	JIRAIDRegex         string
+	KeyPattern          string
+	OutputFile          string
+	Format              string
+	Compact             bool
+	Fields              string
+	PostURL             string
+	PostAuth            string
+	ExtractOnly         bool
+	FailOnEmpty         bool
+	ExtractFromGit      bool
+	CommitRange         bool
+	Help                bool
+	HelpLong            bool
+	GenerateMarkdown    bool
+	MarkdownOutput      string
+	SplitOutput         string
+	FromRef             string
+	ToRef               string
+	IncludeSprints      bool
+	IncludeUserIDs      bool
+	IncludeLinks        bool
+	IncludeSubtasks     bool
+	CollapseTransitions bool
+	Anonymize           bool
+	StatusMap           string
+	IncludeStatus       string
+	ExcludeStatus       string
+	IncludeTypes        string
+	ExcludeTypes        string
+	OnlyErrors          bool
+	TransitionsSince    string
+	Merge               bool
+	MergeStrategy       string
+	NoColor             bool
+	ProxyURL            string
+	Insecure            bool
+	CACertFile          string
+	UserAgent           string
+	Headers             []string
+	MaxIdleConns        int
+	MaxDescription      int
+	IdleConnTimeout     time.Duration
+	NoKeepAlives        bool
+	Workers             int
+	DumpRaw             string
+	LinkStyle           string
+	LinkTemplate        string
+	Watch               bool
+	WatchInterval       time.Duration
+	Path                string
+	DateFormat          string
+	SortBy              string
+	GroupBy             string
+	TOC                 bool
+	Timezone            string
+	ConfigFile          string
+	JQL                 string
+	ScanFile            string
+	ListStatuses        string
+	Quiet               bool
+	LogFormat           string
+	FirstOnly           bool
+	IgnoreCase          bool
+	NoMerges            bool
+	IncludeNotes        bool
+	Strict              bool
+	MaxMessageLength    int
+	WarnDuplicates      bool
+	WithCommitMeta      bool
+	GitPath             string
+	FallbackToRange     bool
+	ContinueOnError     bool
+	DefaultHead         bool
+	UseReflog           bool
+	KeyAliases          []string
+	Append              bool
+	IfNewer             bool
+	Checksum            bool
+	TransitionsCSV      string
+	IgnoreList          string
+	Verbose             bool
+	CredentialsFile     string
+	BaseBranch          string
+	CommitsFile         string
+}
+
+// stringListFlag collects the values of a flag that may be given several times
+type stringListFlag []string
+
+func (s *stringListFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringListFlag) Set(value string) error {
+	*s = append(*s, value)
+	return nil
 }
 
 // ParseFlags parses command line flags
 func ParseFlags() (*FlagConfig, []string) {
 	flags := &FlagConfig{}
 	flag.StringVar(&flags.JIRAIDRegex, "r", "", "JIRA ID regex pattern")
+	flag.StringVar(&flags.KeyPattern, "key-pattern", "", "Named JIRA ID regex instead of -r: default, jira-standard or jira-underscore")
 	flag.StringVar(&flags.OutputFile, "o", "", "Output file for JIRA data")
+	flag.StringVar(&flags.PostURL, "post-url", "", "Also POST the JSON results to this URL")
+	flag.StringVar(&flags.PostAuth, "post-auth", "", "Authorization header for --post-url (default: EVIDENCE_POST_AUTH)")
+	flag.StringVar(&flags.Format, "format", "", "Comma-separated output formats: json, md, html, text, yaml (default: json)")
+	flag.BoolVar(&flags.Compact, "compact", false, "Write the JSON output without indentation")
+	flag.BoolVar(&flags.Compact, "minify", false, "Alias for --compact")
+	flag.StringVar(&flags.Fields, "fields", "", "Comma-separated task fields to write to the JSON (e.g. key,status,transitions; default: all)")
+	flag.BoolVar(&flags.Append, "append", false, "Merge fetched tickets into an existing output file instead of overwriting it")
+	flag.BoolVar(&flags.IfNewer, "if-newer", false, "Skip the run when the output file is newer than HEAD's commit date")
+	flag.BoolVar(&flags.Checksum, "checksum", false, "Write the SHA-256 of the canonical JSON output to OUTPUT.sha256")
+	flag.StringVar(&flags.TransitionsCSV, "transitions-csv", "", "Also write every transition as one CSV row (key, from, to, author, author_email, time) to FILE")
 	flag.BoolVar(&flags.ExtractOnly, "extract-only", false, "Only extract JIRA IDs, don't fetch details")
+	flag.BoolVar(&flags.FailOnEmpty, "fail-on-empty", false, "In extract-only mode, exit with code 2 when no JIRA IDs are found")
 	flag.BoolVar(&flags.ExtractFromGit, "extract-from-git", false, "Extract JIRA IDs from git commits (legacy mode)")
 	flag.BoolVar(&flags.CommitRange, "range", false, "Process commits from the specified commit to HEAD (instead of single commit)")
+	flag.StringVar(&flags.ProxyURL, "proxy", "", "HTTP proxy URL for JIRA requests (default: HTTP_PROXY/HTTPS_PROXY)")
+	flag.BoolVar(&flags.Insecure, "insecure", false, "Skip TLS certificate verification for JIRA requests (not recommended)")
+	flag.StringVar(&flags.CACertFile, "ca-cert", "", "PEM file with additional CA certificates to trust for JIRA requests")
+	flag.StringVar(&flags.LinkStyle, "link-style", "", "Ticket links: browse (default), history or comments tab")
+	flag.StringVar(&flags.LinkTemplate, "link-template", "", "Ticket link template with {base}, {key} and {project} (e.g. {base}/issues/{key})")
+	flag.StringVar(&flags.UserAgent, "user-agent", "", "User-agent for JIRA requests (default: JIRA_USER_AGENT or jira-helper/<version>)")
+	flag.Var((*stringListFlag)(&flags.Headers), "header", "Header added to every JIRA request, as \"Name: Value\" (repeatable)")
+	flag.IntVar(&flags.MaxIdleConns, "max-idle-conns", 0, "Idle connections to JIRA kept open for reuse (default: --workers, at least 2)")
+	flag.DurationVar(&flags.IdleConnTimeout, "idle-conn-timeout", 0, "How long idle connections to JIRA are kept open (default: 90s)")
+	flag.BoolVar(&flags.NoKeepAlives, "no-keep-alives", false, "Open a new connection to JIRA for every request")
+	flag.IntVar(&flags.Workers, "workers", 0, "How many tickets to fetch from JIRA at the same time (default: 1)")
+	flag.StringVar(&flags.DumpRaw, "dump-raw", "", "Debug: write the raw JIRA JSON of every fetched ticket to DIR/KEY.json")
+	flag.BoolVar(&flags.Watch, "watch", false, "Re-run whenever HEAD changes, until interrupted")
+	flag.DurationVar(&flags.WatchInterval, "watch-interval", 0, "How often --watch polls HEAD (default: 2s)")
+	flag.BoolVar(&flags.NoColor, "no-color", false, "Disable colored output")
 	flag.BoolVar(&flags.Help, "h", false, "Display help message")
 	flag.BoolVar(&flags.HelpLong, "help", false, "Display help message")
 	flag.BoolVar(&flags.GenerateMarkdown, "markdown", false, "Generate markdown from existing JSON file")
 	flag.StringVar(&flags.MarkdownOutput, "markdown-output", "", "Output file for markdown (default: transformed_jira_data.md)")
+	flag.StringVar(&flags.SplitOutput, "split-output", "", "With --markdown, write one markdown file per ticket and an index.md into this directory")
+	flag.StringVar(&flags.FromRef, "from", "", "Start ref (commit, tag or branch) of the range to process, excluded (requires --to)")
+	flag.StringVar(&flags.ToRef, "to", "", "End ref (commit, tag or branch) of the range to process, included (requires --from)")
+	flag.StringVar(&flags.BaseBranch, "base-branch", "", "Process the commits on HEAD that aren't on this branch (from their merge-base)")
+	flag.StringVar(&flags.CommitsFile, "commits-file", "", "Process only the commits listed in this file, one hash per line")
+	flag.BoolVar(&flags.ContinueOnError, "continue-on-error", false, "With --commits-file, skip listed commits git fails to read instead of aborting")
+	flag.BoolVar(&flags.FirstOnly, "first-only", false, "Keep only the first JIRA ID of each commit message")
+	flag.BoolVar(&flags.IgnoreCase, "ignore-case", false, "Match the JIRA ID regex case-insensitively and upper-case the matched IDs")
+	flag.BoolVar(&flags.NoMerges, "no-merges", false, "Skip merge commits when scanning a commit range")
+	flag.BoolVar(&flags.IncludeNotes, "include-notes", false, "Also scan the git notes of the scanned commits for JIRA IDs")
+	flag.BoolVar(&flags.FallbackToRange, "fallback-to-range", false, "If the single commit has no JIRA IDs, scan the range from it to HEAD instead")
+	flag.BoolVar(&flags.DefaultHead, "default-head", false, "Analyze the latest commit (HEAD) when no commit or JIRA IDs are given")
+	flag.BoolVar(&flags.WarnDuplicates, "warn-duplicates", false, "Warn about JIRA IDs given more than once (they are always fetched once)")
+	flag.BoolVar(&flags.WithCommitMeta, "with-commit-meta", false, "Record the commits (hash, author, date) that referenced each ticket in a range")
+	flag.StringVar(&flags.GitPath, "git-path", "", "Git executable to run instead of git from PATH (default: GIT_BINARY)")
+	flag.BoolVar(&flags.Strict, "strict", false, "Fail instead of warning when the --range start commit is HEAD (an empty range)")
+	flag.IntVar(&flags.MaxMessageLength, "max-message-length", 0, "Scan only the first N bytes of each commit message for JIRA IDs (default 4096)")
+	flag.BoolVar(&flags.UseReflog, "use-reflog", false, "Experimental: with --range, also scan commits only reachable from the branch's reflog (e.g. rebased away)")
+	flag.StringVar(&flags.IgnoreList, "ignore-list", "", "Comma-separated project keys to drop from extracted IDs (e.g. UTF,SHA,ISO,RFC)")
+	flag.StringVar(&flags.Path, "path", "", "Only extract JIRA IDs from commits that touched this file or directory")
+	flag.BoolVar(&flags.IncludeSprints, "include-sprints", false, "Include sprint names from the agile sprint field")
+	flag.BoolVar(&flags.IncludeUserIDs, "include-user-ids", false, "Include assignee/reporter email (or accountId when hidden)")
+	flag.BoolVar(&flags.IncludeLinks, "include-links", false, "Include linked issues (blocks, is blocked by, relates to, ...)")
+	flag.BoolVar(&flags.IncludeSubtasks, "include-subtasks", false, "Also fetch the subtasks of every fetched issue")
+	flag.BoolVar(&flags.CollapseTransitions, "collapse-transitions", false, "Keep only the first of consecutive identical status transitions")
+	flag.IntVar(&flags.MaxDescription, "max-description", 0, "Truncate ticket descriptions to N characters with an ellipsis (default 0: no limit)")
+	flag.BoolVar(&flags.Anonymize, "anonymize", false, "Replace assignee, reporter and transition author names and emails with User A, User B, ...")
+	flag.StringVar(&flags.StatusMap, "status-map", "", "Comma-separated FROM=TO renames of fetched statuses (e.g. In QA=QA,QA in Progress=QA)")
+	flag.StringVar(&flags.IncludeStatus, "include-status", "", "Comma-separated statuses to keep from the fetched tasks (e.g. In Review,QA)")
+	flag.StringVar(&flags.ExcludeStatus, "exclude-status", "", "Comma-separated statuses to drop from the fetched tasks (e.g. Done,Closed)")
+	flag.StringVar(&flags.IncludeTypes, "include-types", "", "Comma-separated issue types to keep from the fetched tasks (e.g. Bug,Incident)")
+	flag.StringVar(&flags.ExcludeTypes, "exclude-types", "", "Comma-separated issue types to drop from the fetched tasks (e.g. Epic,Sub-task)")
+	flag.BoolVar(&flags.OnlyErrors, "only-errors", false, "Write only the tasks that failed to fetch, for triage")
+	flag.StringVar(&flags.TransitionsSince, "transitions-since", "", "Keep only the transitions made on or after this date (YYYY-MM-DD)")
+	flag.BoolVar(&flags.Merge, "merge", false, "Merge the JSON files given as arguments into the output file")
+	flag.StringVar(&flags.DateFormat, "date-format", "", "Date format for the markdown report: a Go time layout or iso, date, rfc3339")
+	flag.StringVar(&flags.SortBy, "sort-by", "", "Order of the tasks in the markdown report: key, status, priority or created")
+	flag.StringVar(&flags.GroupBy, "group-by", "", "Section the task details of the markdown report: project")
+	flag.BoolVar(&flags.TOC, "toc", false, "Add a table of contents linking to each ticket to the markdown report")
+	flag.StringVar(&flags.ScanFile, "scan-file", "", "Fetch the JIRA IDs referenced in a text file (e.g. a saved PR description) instead of commits")
+	flag.StringVar(&flags.JQL, "jql", "", "Fetch the issues matching a JQL query instead of extracting IDs from commits")
+	flag.StringVar(&flags.ListStatuses, "list-statuses", "", "List the statuses of a JIRA project's workflows and exit")
+	flag.BoolVar(&flags.Verbose, "verbose", false, "Log every git command and JIRA request to stderr")
+	flag.BoolVar(&flags.Quiet, "quiet", false, "Don't print the run summary")
+	flag.StringVar(&flags.LogFormat, "log-format", "", "Format of the run summary: text or json (default: text)")
+	flag.StringVar(&flags.CredentialsFile, "credentials-file", "", "Read JIRA_API_TOKEN, JIRA_URL and JIRA_USERNAME from a file (default: JIRA_CREDENTIALS_FILE)")
+	flag.StringVar(&flags.ConfigFile, "config", "", "Read settings from a YAML or TOML config file")
+	flag.StringVar(&flags.Timezone, "timezone", "", "Convert report dates into this IANA timezone (e.g. UTC, America/New_York)")
+	flag.Var((*stringListFlag)(&flags.KeyAliases), "key-alias", "Fetch tickets of a renamed project under its new key, as OLD=NEW (repeatable)")
+	flag.StringVar(&flags.MergeStrategy, "merge-strategy", "", "Which copy of a duplicate key to keep when merging: last or first (default: last)")
 	flag.Parse()
 
 	return flags, flag.Args()
 }
 
-// LoadConfig loads configuration from flags and environment variables
+// LoadConfig loads configuration from flags, environment variables and an optional config file.
+// Precedence is flags > environment > config file > defaults.
 func LoadConfig(flags *FlagConfig, args []string) (*AppConfig, error) {
+	fileConfig := &FileConfig{}
+	if flags.ConfigFile != "" {
+		var err error
+		if fileConfig, err = LoadConfigFile(flags.ConfigFile); err != nil {
+			return nil, err
+		}
+	}
+
 	config := &AppConfig{
-		JIRAIDRegex:    getOrDefault(flags.JIRAIDRegex, os.Getenv("JIRA_ID_REGEX"), DefaultJIRAIDRegex),
-		OutputFile:     getOrDefault(flags.OutputFile, os.Getenv("OUTPUT_FILE"), DefaultOutputFile),
-		ExtractOnly:    flags.ExtractOnly,
-		ExtractFromGit: flags.ExtractFromGit,
-		SingleCommit:   !flags.CommitRange, // Default to single commit unless --range is specified
+		JIRAIDRegex:         getOrDefault(flags.JIRAIDRegex, os.Getenv("JIRA_ID_REGEX"), fileConfig.JIRAIDRegex, DefaultJIRAIDRegex),
+		OutputFile:          getOrDefault(flags.OutputFile, os.Getenv("OUTPUT_FILE"), fileConfig.OutputFile, DefaultOutputFile),
+		Append:              flags.Append,
+		IfNewer:             flags.IfNewer,
+		ExtractOnly:         flags.ExtractOnly,
+		FailOnEmpty:         flags.FailOnEmpty,
+		ExtractFromGit:      flags.ExtractFromGit,
+		SingleCommit:        !flags.CommitRange && flags.BaseBranch == "", // Default to single commit unless --range or --base-branch is specified
+		FromRef:             flags.FromRef,
+		ToRef:               flags.ToRef,
+		BaseBranch:          flags.BaseBranch,
+		CommitsFile:         flags.CommitsFile,
+		Path:                flags.Path,
+		FirstOnly:           flags.FirstOnly,
+		IgnoreCase:          flags.IgnoreCase || fileConfig.IgnoreCase,
+		NoMerges:            flags.NoMerges,
+		IncludeNotes:        flags.IncludeNotes,
+		Strict:              flags.Strict,
+		WarnDuplicates:      flags.WarnDuplicates,
+		WithCommitMeta:      flags.WithCommitMeta,
+		GitPath:             getOrDefault(flags.GitPath, os.Getenv("GIT_BINARY")),
+		FallbackToRange:     flags.FallbackToRange,
+		UseReflog:           flags.UseReflog,
+		JQL:                 flags.JQL,
+		ScanFile:            flags.ScanFile,
+		Quiet:               flags.Quiet,
+		Verbose:             flags.Verbose,
+		LogFormat:           flags.LogFormat,
+		IncludeSprints:      flags.IncludeSprints || fileConfig.IncludeSprints,
+		IncludeUserIDs:      flags.IncludeUserIDs || fileConfig.IncludeUserIDs,
+		IncludeLinks:        flags.IncludeLinks || fileConfig.IncludeLinks,
+		IncludeSubtasks:     flags.IncludeSubtasks || fileConfig.IncludeSubtasks,
+		CollapseTransitions: flags.CollapseTransitions,
+		Anonymize:           flags.Anonymize || fileConfig.Anonymize,
+		MergeStrategy:       getOrDefault(flags.MergeStrategy, fileConfig.MergeStrategy),
+		ProxyURL:            flags.ProxyURL,
+		Watch:               flags.Watch,
+		WatchInterval:       flags.WatchInterval,
+		CACertFile:          getOrDefault(flags.CACertFile, os.Getenv("JIRA_CA_CERT"), fileConfig.CACertFile),
+		UserAgent:           getOrDefault(flags.UserAgent, os.Getenv("JIRA_USER_AGENT")),
+	}
+
+	// A --key-pattern preset stands in for -r; key_pattern in the config file for jira_id_regex
+	switch {
+	case flags.KeyPattern != "":
+		if flags.JIRAIDRegex != "" {
+			return nil, &evidence.ValidationError{Field: "key-pattern", Value: flags.KeyPattern, Err: fmt.Errorf("cannot be combined with -r")}
+		}
+		regex, err := evidence.ResolveKeyPattern(flags.KeyPattern)
+		if err != nil {
+			return nil, err
+		}
+		config.JIRAIDRegex = regex
+	case fileConfig.KeyPattern != "":
+		if fileConfig.JIRAIDRegex != "" {
+			return nil, &evidence.ValidationError{Field: "key_pattern", Value: fileConfig.KeyPattern, Err: fmt.Errorf("cannot be combined with jira_id_regex")}
+		}
+		regex, err := evidence.ResolveKeyPattern(fileConfig.KeyPattern)
+		if err != nil {
+			return nil, err
+		}
+		// Like jira_id_regex, it gives way to -r and JIRA_ID_REGEX
+		if flags.JIRAIDRegex == "" && os.Getenv("JIRA_ID_REGEX") == "" {
+			config.JIRAIDRegex = regex
+		}
+	}
+
+	// Values from a config file never went through the shell, so expand $VAR and ${VAR} here
+	config.JIRAIDRegex = expandEnv(config.JIRAIDRegex)
+	config.OutputFile = expandEnv(config.OutputFile)
+	config.MarkdownOutput = expandEnv(flags.MarkdownOutput)
+	config.SplitOutput = expandEnv(flags.SplitOutput)
+
+	// The standard proxy environment variables take precedence over a proxy from the config file
+	if config.ProxyURL == "" && !hasProxyEnv() {
+		config.ProxyURL = fileConfig.ProxyURL
+	}
+
+	config.MaxMessageLength = flags.MaxMessageLength
+	config.DefaultHead = flags.DefaultHead || fileConfig.DefaultHead
+	config.ContinueOnError = flags.ContinueOnError
+	config.MaxIdleConns = flags.MaxIdleConns
+	config.MaxDescription = fileConfig.MaxDescription
+	if flags.MaxDescription != 0 {
+		config.MaxDescription = flags.MaxDescription
+	}
+	config.IdleConnTimeout = flags.IdleConnTimeout
+	config.NoKeepAlives = flags.NoKeepAlives
+	config.Workers = flags.Workers
+	config.DumpRawDir = flags.DumpRaw
+	config.Checksum = flags.Checksum || fileConfig.Checksum
+	config.TransitionsCSV = flags.TransitionsCSV
+	config.ListStatuses = strings.TrimSpace(flags.ListStatuses)
+
+	config.InsecureSkipVerify = flags.Insecure || fileConfig.Insecure
+	if value := os.Getenv("JIRA_INSECURE_SKIP_VERIFY"); value != "" && !flags.Insecure {
+		insecure, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, &evidence.ValidationError{Field: "JIRA_INSECURE_SKIP_VERIFY", Value: value, Err: fmt.Errorf("must be a boolean")}
+		}
+		config.InsecureSkipVerify = insecure
+	}
+
+	if dateFormatValue := getOrDefault(flags.DateFormat, fileConfig.DateFormat); dateFormatValue != "" {
+		dateFormat, err := evidence.ResolveDateFormat(dateFormatValue)
+		if err != nil {
+			return nil, err
+		}
+		config.DateFormat = dateFormat
+	}
+
+	statusMap, err := parseStatusMap(fileConfig.StatusMap, flags.StatusMap)
+	if err != nil {
+		return nil, err
+	}
+	if len(statusMap) > 0 {
+		config.StatusMap = statusMap
+	}
+
+	config.IncludeStatuses = parseStatusList(flags.IncludeStatus)
+	config.ExcludeStatuses = parseStatusList(flags.ExcludeStatus)
+	config.IncludeTypes = parseStatusList(flags.IncludeTypes)
+	config.ExcludeTypes = parseStatusList(flags.ExcludeTypes)
+	config.OnlyErrors = flags.OnlyErrors
+	// Failed fetches have no real status to filter on, and appending them would mix them into a full report
+	if config.OnlyErrors && (len(config.IncludeStatuses) > 0 || len(config.ExcludeStatuses) > 0 || config.Append) {
+		return nil, &evidence.ValidationError{Field: "only-errors", Value: "true", Err: fmt.Errorf("cannot be combined with --include-status, --exclude-status or --append")}
+	}
+
+	config.PostURL = flags.PostURL
+	config.PostAuth = getOrDefault(flags.PostAuth, os.Getenv("EVIDENCE_POST_AUTH"))
+	if config.PostURL != "" {
+		if postURL, err := url.Parse(config.PostURL); err != nil || (postURL.Scheme != "http" && postURL.Scheme != "https") || postURL.Host == "" {
+			return nil, &evidence.ValidationError{Field: "post-url", Value: config.PostURL, Err: fmt.Errorf("must be an http or https URL")}
+		}
+	}
+
+	formats, err := parseFormats(flags.Format)
+	if err != nil {
+		return nil, err
+	}
+	config.Formats = formats
+	config.Compact = flags.Compact || fileConfig.Compact
+	if flags.Fields != "" {
+		fields, err := evidence.ParseFields(flags.Fields)
+		if err != nil {
+			return nil, err
+		}
+		config.Fields = fields
+	}
+	if config.Append && !config.writesFormat(FormatJSON) {
+		return nil, &evidence.ValidationError{Field: "append", Value: "true", Err: fmt.Errorf("requires the json format")}
+	}
+	if config.Checksum && !config.writesFormat(FormatJSON) {
+		return nil, &evidence.ValidationError{Field: "checksum", Value: "true", Err: fmt.Errorf("requires the json format")}
+	}
+
+	sortBy, err := evidence.ResolveSortBy(getOrDefault(flags.SortBy, fileConfig.SortBy))
+	if err != nil {
+		return nil, err
+	}
+	config.SortBy = sortBy
+	groupBy, err := evidence.ResolveGroupBy(getOrDefault(flags.GroupBy, fileConfig.GroupBy))
+	if err != nil {
+		return nil, err
+	}
+	config.GroupBy = groupBy
+	config.TOC = flags.TOC || fileConfig.TOC
+
+	if linkTemplate := getOrDefault(flags.LinkTemplate, fileConfig.LinkTemplate); linkTemplate != "" || flags.LinkStyle != "" {
+		if config.LinkTemplate, err = evidence.ResolveLinkTemplate(flags.LinkStyle, linkTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	ignoreKeyValues := fileConfig.IgnoreList
+	if flags.IgnoreList != "" {
+		ignoreKeyValues = strings.Split(flags.IgnoreList, ",")
+	}
+	ignoreKeys, err := parseIgnoreList(ignoreKeyValues)
+	if err != nil {
+		return nil, err
+	}
+	config.IgnoreKeys = ignoreKeys
+
+	keyAliases, err := parseKeyAliases(append(append([]string{}, fileConfig.KeyAliases...), flags.KeyAliases...))
+	if err != nil {
+		return nil, err
+	}
+	if len(keyAliases) > 0 {
+		config.KeyAliases = keyAliases
+	}
+
+	headers, err := parseHeaders(append(append([]string{}, fileConfig.Headers...), flags.Headers...))
+	if err != nil {
+		return nil, err
+	}
+	if len(headers) > 0 {
+		config.Headers = headers
+	}
+
+	if len(fileConfig.ProjectRegexes) > 0 {
+		if _, err := evidence.CompileJiraIDRegex(config.JIRAIDRegex, fileConfig.ProjectRegexes); err != nil {
+			return nil, err
+		}
+		config.ProjectRegexes = fileConfig.ProjectRegexes
+	}
+
+	// Like the top-level regex, a rule's regex gives way to -r, --key-pattern and JIRA_ID_REGEX
+	regexGiven := flags.JIRAIDRegex != "" || flags.KeyPattern != "" || os.Getenv("JIRA_ID_REGEX") != ""
+	if config.BranchRules, err = resolveBranchRules(fileConfig.BranchRules, config.JIRAIDRegex, regexGiven); err != nil {
+		return nil, err
+	}
+
+	if timezone := getOrDefault(flags.Timezone, fileConfig.Timezone); timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return nil, &evidence.ValidationError{Field: "timezone", Value: timezone, Err: err}
+		}
+		config.Location = location
+	}
+
+	// Parsed after --timezone so the date starts at midnight in the report's time zone
+	if flags.TransitionsSince != "" {
+		location := config.Location
+		if location == nil {
+			location = time.UTC
+		}
+		since, err := time.ParseInLocation(transitionsSinceLayout, flags.TransitionsSince, location)
+		if err != nil {
+			return nil, &evidence.ValidationError{Field: "transitions-since", Value: flags.TransitionsSince, Err: fmt.Errorf("must be a date like 2024-01-01")}
+		}
+		config.TransitionsSince = since
+	}
+
+	if config.MaxMessageLength < 0 {
+		return nil, &evidence.ValidationError{Field: "max-message-length", Value: strconv.Itoa(config.MaxMessageLength), Err: fmt.Errorf("must be positive")}
+	}
+
+	if config.MaxDescription < 0 {
+		return nil, &evidence.ValidationError{Field: "max-description", Value: strconv.Itoa(config.MaxDescription), Err: fmt.Errorf("must be positive")}
+	}
+
+	if config.MaxIdleConns < 0 {
+		return nil, &evidence.ValidationError{Field: "max-idle-conns", Value: strconv.Itoa(config.MaxIdleConns), Err: fmt.Errorf("must be positive")}
+	}
+
+	if config.Workers < 0 {
+		return nil, &evidence.ValidationError{Field: "workers", Value: strconv.Itoa(config.Workers), Err: fmt.Errorf("must be positive")}
+	}
+
+	if config.IdleConnTimeout < 0 {
+		return nil, &evidence.ValidationError{Field: "idle-conn-timeout", Value: config.IdleConnTimeout.String(), Err: fmt.Errorf("must be positive")}
+	}
+
+	if config.WatchInterval < 0 {
+		return nil, &evidence.ValidationError{Field: "watch-interval", Value: config.WatchInterval.String(), Err: fmt.Errorf("must be positive")}
+	}
+
+	switch config.LogFormat {
+	case "", evidence.LogFormatText, evidence.LogFormatJSON:
+	default:
+		return nil, &evidence.ValidationError{Field: "log-format", Value: config.LogFormat, Err: fmt.Errorf("must be '%s' or '%s'", evidence.LogFormatText, evidence.LogFormatJSON)}
+	}
+
+	switch config.MergeStrategy {
+	case "", MergeKeepLast, MergeKeepFirst:
+	default:
+		return nil, &evidence.ValidationError{Field: "merge-strategy", Value: config.MergeStrategy, Err: fmt.Errorf("must be '%s' or '%s'", MergeKeepLast, MergeKeepFirst)}
+	}
+
+	// --from and --to describe a single range and must be used together
+	if config.FromRef != "" && config.ToRef == "" {
+		return nil, &evidence.ValidationError{Field: "to", Value: "", Err: fmt.Errorf("required when --from is set")}
+	}
+	if config.ToRef != "" && config.FromRef == "" {
+		return nil, &evidence.ValidationError{Field: "from", Value: "", Err: fmt.Errorf("required when --to is set")}
+	}
+
+	// --base-branch derives the start commit itself
+	if config.BaseBranch != "" && (len(args) > 0 || flags.CommitRange || config.FromRef != "") {
+		return nil, &evidence.ValidationError{Field: "base-branch", Value: config.BaseBranch, Err: fmt.Errorf("cannot be combined with a start commit, --range or --from/--to")}
+	}
+
+	// --commits-file lists the commits itself
+	if config.CommitsFile != "" && (len(args) > 0 || flags.CommitRange || config.FromRef != "" || config.BaseBranch != "") {
+		return nil, &evidence.ValidationError{Field: "commits-file", Value: config.CommitsFile, Err: fmt.Errorf("cannot be combined with a start commit, --range, --from/--to or --base-branch")}
 	}
 
-	// Load JIRA credentials only if not in extract-only mode or markdown mode
-	if !config.ExtractOnly && !config.ExtractFromGit && !flags.GenerateMarkdown {
-		config.JIRAToken = os.Getenv("JIRA_API_TOKEN")
-		config.JIRAURL = os.Getenv("JIRA_URL")
-		config.JIRAUsername = os.Getenv("JIRA_USERNAME")
+	// The reflog is scanned back to the start commit, so it needs --range with a positional commit
+	if config.UseReflog && (!flags.CommitRange || config.FromRef != "" || config.BaseBranch != "") {
+		return nil, &evidence.ValidationError{Field: "use-reflog", Value: "true", Err: fmt.Errorf("requires --range with a start commit")}
+	}
+
+	// The fallback widens a single commit to a range, so every other commit selection already is one
+	if config.FallbackToRange && (flags.CommitRange || config.FromRef != "" || config.BaseBranch != "" || config.CommitsFile != "") {
+		return nil, &evidence.ValidationError{Field: "fallback-to-range", Value: "true", Err: fmt.Errorf("only applies to single-commit mode")}
+	}
+
+	// Per-ticket files replace the single markdown report, so they need markdown mode and its own output
+	if flags.SplitOutput != "" && (!flags.GenerateMarkdown || flags.MarkdownOutput != "") {
+		return nil, &evidence.ValidationError{Field: "split-output", Value: flags.SplitOutput, Err: fmt.Errorf("requires --markdown and cannot be combined with --markdown-output")}
+	}
+	// Each ticket gets its own file there, so there are no task details to section
+	if flags.SplitOutput != "" && config.GroupBy != "" {
+		return nil, &evidence.ValidationError{Field: "group-by", Value: config.GroupBy, Err: fmt.Errorf("cannot be combined with --split-output")}
+	}
+
+	// Commit metadata is collected from one git log of the scanned range
+	if config.WithCommitMeta && ((!flags.CommitRange && config.FromRef == "" && config.BaseBranch == "") || config.UseReflog || config.ExtractOnly) {
+		return nil, &evidence.ValidationError{Field: "with-commit-meta", Value: "true", Err: fmt.Errorf("requires a commit range (--range, --from/--to or --base-branch) without --use-reflog or --extract-only")}
+	}
+
+	// The staleness check compares the output file with HEAD, so it needs a run that fetches from commits
+	if config.IfNewer && (config.ExtractOnly || config.ExtractFromGit || config.JQL != "" || config.ScanFile != "" || flags.ListStatuses != "" || flags.GenerateMarkdown || flags.Merge) {
+		return nil, &evidence.ValidationError{Field: "if-newer", Value: "true", Err: fmt.Errorf("only applies to fetching tickets from commits, not to --extract-only, --jql, --scan-file, --list-statuses, --markdown or --merge")}
+	}
+
+	// The CSV is written next to the fetched or merged results, which the other modes don't produce
+	if config.TransitionsCSV != "" && (config.ExtractOnly || config.ExtractFromGit || flags.ListStatuses != "" || flags.GenerateMarkdown) {
+		return nil, &evidence.ValidationError{Field: "transitions-csv", Value: config.TransitionsCSV, Err: fmt.Errorf("cannot be combined with --extract-only, --list-statuses or --markdown")}
+	}
+
+	// Only --commits-file processes a list of commits where one can be skipped
+	if config.ContinueOnError && config.CommitsFile == "" {
+		return nil, &evidence.ValidationError{Field: "continue-on-error", Value: "true", Err: fmt.Errorf("requires --commits-file")}
+	}
+
+	// JQL mode sources the ticket set from JIRA, so it can't be combined with commits or direct IDs
+	if config.JQL != "" {
+		if len(args) > 0 || flags.CommitRange || config.FromRef != "" || config.BaseBranch != "" || config.CommitsFile != "" || config.ExtractOnly || config.ExtractFromGit ||
+			config.Watch || flags.GenerateMarkdown || flags.Merge {
+			return nil, &evidence.ValidationError{Field: "jql", Value: config.JQL, Err: fmt.Errorf("cannot be combined with commit arguments, JIRA IDs or other modes")}
+		}
+	}
+
+	// Scan-file mode sources the ticket set from a text file, so it can't be combined with commits or direct IDs either
+	if config.ScanFile != "" {
+		if len(args) > 0 || flags.CommitRange || config.FromRef != "" || config.BaseBranch != "" || config.CommitsFile != "" || config.JQL != "" ||
+			config.ExtractOnly || config.ExtractFromGit || config.Watch || flags.GenerateMarkdown || flags.Merge {
+			return nil, &evidence.ValidationError{Field: "scan-file", Value: config.ScanFile, Err: fmt.Errorf("cannot be combined with commit arguments, JIRA IDs, --jql or other modes")}
+		}
+	}
+
+	// Listing a project's statuses is a read-only helper that fetches no tickets
+	if flags.ListStatuses != "" {
+		if config.ListStatuses == "" {
+			return nil, &evidence.ValidationError{Field: "list-statuses", Value: flags.ListStatuses, Err: fmt.Errorf("project key cannot be empty")}
+		}
+		if len(args) > 0 || flags.CommitRange || config.FromRef != "" || config.BaseBranch != "" || config.CommitsFile != "" || config.JQL != "" || config.ScanFile != "" ||
+			config.ExtractOnly || config.ExtractFromGit || config.Watch || flags.GenerateMarkdown || flags.Merge {
+			return nil, &evidence.ValidationError{Field: "list-statuses", Value: config.ListStatuses, Err: fmt.Errorf("cannot be combined with commit arguments, JIRA IDs or other modes")}
+		}
+	}
+
+	// Load JIRA credentials only if not in extract-only, markdown or merge mode
+	if !config.ExtractOnly && !config.ExtractFromGit && !flags.GenerateMarkdown && !flags.Merge {
+		credentials := &Credentials{}
+		if credentialsFile := getOrDefault(flags.CredentialsFile, os.Getenv("JIRA_CREDENTIALS_FILE")); credentialsFile != "" {
+			if credentials, err = LoadCredentialsFile(credentialsFile); err != nil {
+				return nil, err
+			}
+		}
+
+		config.JIRAToken = getOrDefault(os.Getenv("JIRA_API_TOKEN"), credentials.Token)
+		config.JIRAURL = getOrDefault(os.Getenv("JIRA_URL"), credentials.URL, fileConfig.JIRAURL)
+		config.JIRAUsername = getOrDefault(os.Getenv("JIRA_USERNAME"), credentials.Username, fileConfig.JIRAUsername)
 
 		// Validate JIRA configuration
 		if err := validateJIRAConfig(config); err != nil {
@@ -89,17 +722,262 @@ func LoadConfig(flags *FlagConfig, args []string) (*AppConfig, error) {
 // validateJIRAConfig validates JIRA-related configuration
 func validateJIRAConfig(config *AppConfig) error {
 	if config.JIRAToken == "" {
-		return &ValidationError{Field: "JIRA_API_TOKEN", Value: "", Err: fmt.Errorf("environment variable is required")}
+		return &evidence.ValidationError{Field: "JIRA_API_TOKEN", Value: "", Err: fmt.Errorf("environment variable is required")}
 	}
 	if config.JIRAURL == "" {
-		return &ValidationError{Field: "JIRA_URL", Value: "", Err: fmt.Errorf("environment variable is required")}
+		return &evidence.ValidationError{Field: "JIRA_URL", Value: "", Err: fmt.Errorf("environment variable is required")}
 	}
 	if config.JIRAUsername == "" {
-		return &ValidationError{Field: "JIRA_USERNAME", Value: "", Err: fmt.Errorf("environment variable is required")}
+		return &evidence.ValidationError{Field: "JIRA_USERNAME", Value: "", Err: fmt.Errorf("environment variable is required")}
+	}
+	return nil
+}
+
+// projectKeyPattern matches a JIRA project key such as EV or OPS2
+var projectKeyPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+
+// parseFormats parses a comma-separated --format value. "markdown" is accepted for md and "yml"
+// for yaml, and duplicates are dropped. An empty value returns nil, which means JSON only.
+func parseFormats(value string) ([]string, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var formats []string
+	seen := make(map[string]bool)
+	for _, format := range strings.Split(value, ",") {
+		format = strings.ToLower(strings.TrimSpace(format))
+		switch format {
+		case "markdown":
+			format = FormatMarkdown
+		case "yml":
+			format = FormatYAML
+		}
+		switch format {
+		case FormatJSON, FormatMarkdown, FormatHTML, FormatText, FormatYAML:
+		default:
+			return nil, &evidence.ValidationError{Field: "format", Value: value, Err: fmt.Errorf("each format must be one of: %s, %s, %s, %s, %s", FormatJSON, FormatMarkdown, FormatHTML, FormatText, FormatYAML)}
+		}
+		if !seen[format] {
+			seen[format] = true
+			formats = append(formats, format)
+		}
+	}
+	return formats, nil
+}
+
+// parseStatusList splits a comma-separated list of JIRA statuses (or issue types), skipping blank
+// entries. Statuses may contain spaces (e.g. "In Review") and are matched case-insensitively later.
+func parseStatusList(value string) []string {
+	var statuses []string
+	for _, status := range strings.Split(value, ",") {
+		if status = strings.TrimSpace(status); status != "" {
+			statuses = append(statuses, status)
+		}
+	}
+	return statuses
+}
+
+// resolveBranchRules validates the branch_rules of the config file and resolves their key_pattern
+// presets into regexes. When the regex was given on the command line or in the environment
+// (regexGiven), the rules' regexes are dropped so that it is used on every branch.
+func resolveBranchRules(rules []BranchRule, jiraIDRegex string, regexGiven bool) ([]BranchRule, error) {
+	var resolved []BranchRule
+	for _, rule := range rules {
+		field := "branch_rules." + rule.Branch
+		if strings.TrimSpace(rule.Branch) == "" {
+			return nil, &evidence.ValidationError{Field: "branch_rules", Value: rule.Branch, Err: fmt.Errorf("every rule needs a branch pattern")}
+		}
+		if _, err := path.Match(rule.Branch, ""); err != nil {
+			return nil, &evidence.ValidationError{Field: field, Value: rule.Branch, Err: fmt.Errorf("invalid branch pattern: %v", err)}
+		}
+		if rule.JIRAIDRegex == "" && rule.KeyPattern == "" && len(rule.ProjectRegexes) == 0 {
+			return nil, &evidence.ValidationError{Field: field, Value: rule.Branch, Err: fmt.Errorf("sets none of jira_id_regex, key_pattern or project_regexes")}
+		}
+
+		if rule.KeyPattern != "" {
+			if rule.JIRAIDRegex != "" {
+				return nil, &evidence.ValidationError{Field: field, Value: rule.KeyPattern, Err: fmt.Errorf("key_pattern cannot be combined with jira_id_regex")}
+			}
+			regex, err := evidence.ResolveKeyPattern(rule.KeyPattern)
+			if err != nil {
+				return nil, err
+			}
+			rule.JIRAIDRegex, rule.KeyPattern = regex, ""
+		}
+		rule.JIRAIDRegex = expandEnv(rule.JIRAIDRegex)
+		if regexGiven {
+			rule.JIRAIDRegex = ""
+		}
+
+		if _, err := evidence.CompileJiraIDRegex(getOrDefault(rule.JIRAIDRegex, jiraIDRegex), rule.ProjectRegexes); err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, rule)
+	}
+	return resolved, nil
+}
+
+// branchRule returns the first branch rule whose pattern matches branchName, or nil when none does
+// (or HEAD is detached, which has no branch name)
+func (c *AppConfig) branchRule(branchName string) *BranchRule {
+	if branchName == "" {
+		return nil
+	}
+	for i, rule := range c.BranchRules {
+		if matched, _ := path.Match(rule.Branch, branchName); matched {
+			return &c.BranchRules[i]
+		}
 	}
 	return nil
 }
 
+// forBranch returns the configuration to extract with on branchName: a copy using the regexes of
+// the matching branch rule, or the configuration itself when no rule matches
+func (c *AppConfig) forBranch(branchName string) (*AppConfig, *BranchRule) {
+	rule := c.branchRule(branchName)
+	if rule == nil {
+		return c, nil
+	}
+
+	branchConfig := *c
+	if rule.JIRAIDRegex != "" {
+		branchConfig.JIRAIDRegex = rule.JIRAIDRegex
+	}
+	if len(rule.ProjectRegexes) > 0 {
+		branchConfig.ProjectRegexes = rule.ProjectRegexes
+	}
+	return &branchConfig, rule
+}
+
+// parseStatusMap merges the status_map of the config file with the comma-separated FROM=TO entries
+// of --status-map, which override file entries for the same status. Statuses are matched
+// case-insensitively, so the map is keyed by the lower-cased FROM status.
+func parseStatusMap(fileEntries map[string]string, value string) (map[string]string, error) {
+	statusMap := make(map[string]string)
+	add := func(from, to, entry string) error {
+		from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+		if from == "" || to == "" {
+			return &evidence.ValidationError{Field: "status-map", Value: entry, Err: fmt.Errorf("must be FROM=TO statuses, e.g. \"In QA=QA\"")}
+		}
+		if strings.EqualFold(to, evidence.ErrorStatus) {
+			return &evidence.ValidationError{Field: "status-map", Value: entry, Err: fmt.Errorf("cannot map to %s, which marks tickets that failed to fetch", evidence.ErrorStatus)}
+		}
+		statusMap[strings.ToLower(from)] = to
+		return nil
+	}
+
+	// Sorted, so statuses repeated in different case resolve the same way on every run
+	fromStatuses := make([]string, 0, len(fileEntries))
+	for from := range fileEntries {
+		fromStatuses = append(fromStatuses, from)
+	}
+	sort.Strings(fromStatuses)
+	for _, from := range fromStatuses {
+		if err := add(from, fileEntries[from], from+"="+fileEntries[from]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
+		}
+		from, to, _ := strings.Cut(entry, "=")
+		if err := add(from, to, entry); err != nil {
+			return nil, err
+		}
+	}
+	return statusMap, nil
+}
+
+// writesFormat reports whether full-mode runs write the given output format
+func (c *AppConfig) writesFormat(format string) bool {
+	if len(c.Formats) == 0 {
+		return format == FormatJSON
+	}
+	for _, f := range c.Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// parseIgnoreList normalizes the project keys of --ignore-list to upper case, skipping blank entries
+func parseIgnoreList(values []string) ([]string, error) {
+	var keys []string
+	for _, value := range values {
+		key := strings.TrimSpace(value)
+		if key == "" {
+			continue
+		}
+		if !projectKeyPattern.MatchString(key) {
+			return nil, &evidence.ValidationError{Field: "ignore-list", Value: value, Err: fmt.Errorf("must be a project key such as UTF")}
+		}
+		keys = append(keys, strings.ToUpper(key))
+	}
+	return keys, nil
+}
+
+// parseKeyAliases parses OLD=NEW project key aliases. Later entries override earlier ones for the same OLD key.
+func parseKeyAliases(values []string) (map[string]string, error) {
+	aliases := make(map[string]string, len(values))
+	for _, value := range values {
+		oldKey, newKey, found := strings.Cut(value, "=")
+		oldKey, newKey = strings.TrimSpace(oldKey), strings.TrimSpace(newKey)
+		if !found || !projectKeyPattern.MatchString(oldKey) || !projectKeyPattern.MatchString(newKey) {
+			return nil, &evidence.ValidationError{Field: "key-alias", Value: value, Err: fmt.Errorf("must be OLD=NEW project keys, e.g. OLD=NEW")}
+		}
+		if strings.EqualFold(oldKey, newKey) {
+			return nil, &evidence.ValidationError{Field: "key-alias", Value: value, Err: fmt.Errorf("old and new keys must differ")}
+		}
+		aliases[strings.ToUpper(oldKey)] = strings.ToUpper(newKey)
+	}
+	return aliases, nil
+}
+
+// headerNamePattern matches a valid HTTP header name (an RFC 7230 token)
+var headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+// reservedHeaders are set by the tool itself and can't be given with --header
+var reservedHeaders = map[string]string{
+	"Authorization":  "JIRA credentials come from JIRA_API_TOKEN and JIRA_USERNAME",
+	"User-Agent":     "use --user-agent",
+	"Host":           "it is derived from JIRA_URL",
+	"Content-Length": "it is computed for each request",
+}
+
+// parseHeaders parses "Name: Value" request headers. Repeating a name sends every value.
+func parseHeaders(values []string) (http.Header, error) {
+	headers := make(http.Header, len(values))
+	for _, value := range values {
+		name, headerValue, found := strings.Cut(value, ":")
+		name, headerValue = strings.TrimSpace(name), strings.TrimSpace(headerValue)
+		if !found || !headerNamePattern.MatchString(name) {
+			return nil, &evidence.ValidationError{Field: "header", Value: value, Err: fmt.Errorf("must be \"Name: Value\" with a valid header name, e.g. \"X-Atlassian-Token: no-check\"")}
+		}
+		if strings.ContainsAny(headerValue, "\r\n\x00") {
+			return nil, &evidence.ValidationError{Field: "header", Value: name, Err: fmt.Errorf("value cannot contain line breaks or NUL characters")}
+		}
+		if reason, reserved := reservedHeaders[http.CanonicalHeaderKey(name)]; reserved {
+			return nil, &evidence.ValidationError{Field: "header", Value: name, Err: fmt.Errorf("cannot be overridden, %s", reason)}
+		}
+		headers.Add(name, headerValue)
+	}
+	return headers, nil
+}
+
+// hasProxyEnv reports whether any of the standard proxy environment variables is set
+func hasProxyEnv() bool {
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+		if os.Getenv(name) != "" {
+			return true
+		}
+	}
+	return false
+}
+
 // getOrDefault gets value with defaults
 func getOrDefault(values ...string) string {
 	for _, v := range values {
@@ -110,6 +988,18 @@ func getOrDefault(values ...string) string {
 	return ""
 }
 
+// expandEnv replaces $VAR and ${VAR} with the environment variable's value like os.ExpandEnv,
+// except that $$ stands for a literal $. A $ not followed by a name (such as a regex's
+// end-of-line anchor) is kept as is.
+func expandEnv(value string) string {
+	return os.Expand(value, func(name string) string {
+		if name == "$" {
+			return "$"
+		}
+		return os.Getenv(name)
+	})
+}
+
 // DisplayUsage shows the usage information
 func DisplayUsage() {
 	fmt.Println("JIRA Evidence Gathering Tool")
@@ -117,15 +1007,91 @@ func DisplayUsage() {
 	fmt.Println("Usage:")
 	fmt.Println("  ./main [OPTIONS] <start_commit>")
 	fmt.Println("  ./main <jira_id1> [jira_id2] [jira_id3] ...")
+	fmt.Println("  ./main --merge <file1.json> <file2.json> ... -o <combined.json>")
 	fmt.Println("")
 	fmt.Println("Options:")
 	fmt.Println("  -r, --regex PATTERN    JIRA ID regex pattern (default: '[A-Z]+-[0-9]+')")
+	fmt.Println("  --key-pattern NAME     Named JIRA ID regex: default, jira-standard (keys with digits, e.g. AB2C-1), jira-underscore")
 	fmt.Println("  -o, --output FILE      Output file for JIRA data (default: transformed_jira_data.json)")
+	fmt.Println("  --format LIST          Output formats written by one run: json, md, html, text, yaml (default: json)")
+	fmt.Println("  --compact, --minify    Write the JSON output without indentation (smaller files)")
+	fmt.Println("  --fields LIST          Task fields to write to the JSON, e.g. key,status,transitions (default: all)")
+	fmt.Println("  --post-url URL         Also POST the JSON results to URL (e.g. an evidence intake service)")
+	fmt.Println("  --post-auth VALUE      Authorization header for --post-url (prefer EVIDENCE_POST_AUTH)")
+	fmt.Println("  --append               Merge fetched tickets into an existing output file instead of overwriting it")
+	fmt.Println("  --if-newer             Skip the run when the output file is newer than HEAD's commit date")
+	fmt.Println("  --checksum             Also write the SHA-256 of the canonical JSON output to OUTPUT.sha256")
+	fmt.Println("  --transitions-csv FILE Also write every transition as one CSV row to FILE, for spreadsheets and BI tools")
+	fmt.Println("  --config FILE          Read settings from a YAML or TOML config file")
+	fmt.Println("  --credentials-file F   Read JIRA_API_TOKEN, JIRA_URL and JIRA_USERNAME from a file (chmod 600)")
 	fmt.Println("  --extract-only         Only extract JIRA IDs, don't fetch details")
+	fmt.Println("  --fail-on-empty        With --extract-only, exit with code 2 when no JIRA IDs are found")
 	fmt.Println("  --extract-from-git     Extract JIRA IDs from git commits (legacy mode)")
 	fmt.Println("  --range                Process commits from the specified commit to HEAD (instead of single commit)")
+	fmt.Println("  --from REF --to REF    Process commits in the range REF..REF (e.g. between two tags)")
+	fmt.Println("  --base-branch BRANCH   Process the commits on HEAD that aren't on BRANCH (PR-style, from the merge-base)")
+	fmt.Println("  --commits-file FILE    Process only the commits listed in FILE, one hash per line")
+	fmt.Println("  --continue-on-error    With --commits-file, skip commits git fails to read instead of aborting")
+	fmt.Println("  --jql QUERY            Fetch the issues matching a JQL query instead of commits")
+	fmt.Println("  --scan-file FILE       Fetch the JIRA IDs referenced in a text file (e.g. a PR description) instead of commits")
+	fmt.Println("  --list-statuses KEY    List the statuses of a project's workflows (for --include-status/--exclude-status)")
+	fmt.Println("  --first-only           Keep only the first JIRA ID of each commit message")
+	fmt.Println("  --ignore-case          Match the JIRA ID regex case-insensitively (ev-123 is fetched as EV-123)")
+	fmt.Println("  --no-merges            Skip merge commits when scanning a commit range")
+	fmt.Println("  --include-notes        Also scan the git notes of the scanned commits for JIRA IDs")
+	fmt.Println("  --strict               Fail instead of warning when the --range start commit is HEAD")
+	fmt.Println("  --max-message-length N Scan only the first N bytes of each commit message (default 4096)")
+	fmt.Println("  --fallback-to-range    If the single commit has no JIRA IDs, scan the range from it to HEAD")
+	fmt.Println("  --default-head         Analyze the latest commit (HEAD) when no commit or JIRA IDs are given")
+	fmt.Println("  --warn-duplicates      Warn about JIRA IDs given more than once (each is fetched once)")
+	fmt.Println("  --with-commit-meta     Record the commits (hash, author, date) referencing each ticket in a range")
+	fmt.Println("  --git-path PATH        Git executable to run instead of git from PATH")
+	fmt.Println("  --use-reflog           Experimental: with --range, also scan rebased/force-pushed commits from the reflog")
+	fmt.Println("  --ignore-list KEYS     Drop extracted IDs with these project keys, e.g. UTF,SHA,ISO,RFC")
+	fmt.Println("  --path FILE            Only extract JIRA IDs from commits that touched this file or directory")
+	fmt.Println("  --key-alias OLD=NEW    Fetch tickets of a renamed project under its new key (repeatable)")
+	fmt.Println("  --include-sprints      Include sprint names from the agile sprint field")
+	fmt.Println("  --include-user-ids     Include assignee/reporter email (or accountId when the email is hidden)")
+	fmt.Println("  --include-links        Include linked issues (blocks, is blocked by, relates to, ...)")
+	fmt.Println("  --include-subtasks     Also fetch the subtasks of every fetched issue")
+	fmt.Println("  --collapse-transitions Keep only the first of consecutive identical status transitions")
+	fmt.Println("  --max-description N    Truncate descriptions to N characters with an ellipsis (default 0: no limit)")
+	fmt.Println("  --anonymize            Replace people's names and emails with User A, User B, ... in the JSON and markdown")
+	fmt.Println("  --status-map MAP       Rename fetched statuses, as FROM=TO,FROM=TO (e.g. In QA=QA,QA in Progress=QA)")
+	fmt.Println("  --include-status LIST  Keep only fetched tasks in these statuses (failed fetches are kept)")
+	fmt.Println("  --exclude-status LIST  Drop fetched tasks in these statuses from the output (e.g. Done,Closed)")
+	fmt.Println("  --include-types LIST   Keep only fetched tasks of these issue types (failed fetches are kept)")
+	fmt.Println("  --exclude-types LIST   Drop fetched tasks of these issue types from the output (e.g. Epic,Sub-task)")
+	fmt.Println("  --only-errors          Write only the tasks that failed to fetch (an empty list if none did)")
+	fmt.Println("  --transitions-since D  Keep only transitions made on or after date D (YYYY-MM-DD)")
 	fmt.Println("  --markdown             Generate markdown from existing JSON file")
 	fmt.Println("  --markdown-output FILE Output file for markdown (default: transformed_jira_data.md)")
+	fmt.Println("  --split-output DIR     With --markdown, write DIR/<KEY>.md per ticket and DIR/index.md")
+	fmt.Println("  --date-format FORMAT   Report date format: Go layout or iso, date, rfc3339")
+	fmt.Println("  --timezone ZONE        Convert report dates into an IANA timezone (e.g. UTC)")
+	fmt.Println("  --sort-by FIELD        Order report tasks by key, status, priority or created")
+	fmt.Println("  --group-by project     Section the markdown task details under a heading per project")
+	fmt.Println("  --toc                  Add a table of contents linking to each ticket to the markdown report")
+	fmt.Println("  --merge                Merge the JSON files given as arguments into the output file")
+	fmt.Println("  --merge-strategy S     Which copy of a duplicate key to keep when merging: last or first (default: last)")
+	fmt.Println("  --proxy URL            HTTP proxy URL for JIRA requests (default: HTTP_PROXY/HTTPS_PROXY)")
+	fmt.Println("  --insecure             Skip TLS certificate verification for JIRA requests (not recommended)")
+	fmt.Println("  --ca-cert FILE         PEM file with additional CA certificates to trust for JIRA requests")
+	fmt.Println("  --link-style STYLE     Ticket links: browse (default), history or comments tab")
+	fmt.Println("  --link-template T      Ticket link template with {base}, {key} and {project}")
+	fmt.Println("  --user-agent UA        User-agent for JIRA requests (default: jira-helper/<version>)")
+	fmt.Println("  --header \"N: V\"        Add a header to every JIRA request, e.g. for a gateway (repeatable)")
+	fmt.Println("  --max-idle-conns N     Idle connections to JIRA kept open for reuse (default: --workers, at least 2)")
+	fmt.Println("  --idle-conn-timeout D  How long idle connections to JIRA are kept open (default: 90s)")
+	fmt.Println("  --no-keep-alives       Open a new connection to JIRA for every request")
+	fmt.Println("  --workers N            How many tickets to fetch from JIRA at the same time (default: 1)")
+	fmt.Println("  --dump-raw DIR         Debug: write the raw JIRA JSON of every fetched ticket to DIR/KEY.json")
+	fmt.Println("  --watch                Re-run whenever HEAD changes, until interrupted")
+	fmt.Println("  --watch-interval DUR   How often --watch polls HEAD, e.g. 5s (default: 2s)")
+	fmt.Println("  --verbose              Log every git command and JIRA request to stderr")
+	fmt.Println("  --quiet                Don't print the run summary (duration, git commands, JIRA API calls)")
+	fmt.Println("  --log-format FORMAT    Format of the run summary: text or json (default: text)")
+	fmt.Println("  --no-color             Disable colored output (also honors NO_COLOR)")
 	fmt.Println("  -h, --help             Display this help message")
 	fmt.Println("")
 	fmt.Println("Arguments:")
@@ -136,15 +1102,30 @@ func DisplayUsage() {
 	fmt.Println("  JIRA_API_TOKEN         JIRA API token")
 	fmt.Println("  JIRA_URL              JIRA instance URL")
 	fmt.Println("  JIRA_USERNAME         JIRA username")
+	fmt.Println("  JIRA_CREDENTIALS_FILE File with the three variables above (can be overridden with --credentials-file)")
 	fmt.Println("  JIRA_ID_REGEX         JIRA ID regex pattern (can be overridden with -r)")
 	fmt.Println("  OUTPUT_FILE           Output file path (can be overridden with -o)")
+	fmt.Println("  HTTP_PROXY/HTTPS_PROXY Proxy for JIRA requests (NO_PROXY lists exceptions)")
+	fmt.Println("  JIRA_CA_CERT          PEM file with additional trusted CAs (can be overridden with --ca-cert)")
+	fmt.Println("  JIRA_INSECURE_SKIP_VERIFY Set to true to skip TLS verification (same as --insecure)")
+	fmt.Println("  EVIDENCE_POST_AUTH    Authorization header for --post-url (can be overridden with --post-auth)")
+	fmt.Println("  GIT_BINARY            Git executable to run (can be overridden with --git-path)")
+	fmt.Println("  JIRA_USER_AGENT       User-agent for JIRA requests (can be overridden with --user-agent)")
+	fmt.Println("  NO_COLOR              Disable colored output when set to any value")
 	fmt.Println("")
 	fmt.Println("Examples:")
 	fmt.Println("  ./main abc123def456                   # Process only commit abc123def456")
 	fmt.Println("  ./main --range abc123def456           # Process commits from abc123def456 to HEAD")
+	fmt.Println("  ./main --from v1.0.0 --to v1.1.0     # Process commits between two tags")
+	fmt.Println("  ./main --base-branch main            # Process the commits of the current branch only")
+	fmt.Println("  ./main --commits-file approved.txt   # Process only the listed commits")
 	fmt.Println("  ./main -r 'EV-\\d+' -o jira_results.json abc123def456")
 	fmt.Println("  ./main --extract-only abc123def456")
 	fmt.Println("  ./main EV-123 EV-456 EV-789         # Direct JIRA ticket processing")
+	fmt.Println("  ./main --jql 'fixVersion = 1.2.3'   # Process all tickets matching a JQL query")
+	fmt.Println("  ./main --scan-file pr_body.md       # Process the tickets referenced in a PR description")
+	fmt.Println("  ./main --list-statuses EV           # Show the statuses used in project EV")
 	fmt.Println("  ./main --markdown                    # Generate markdown from transformed_jira_data.json")
 	fmt.Println("  ./main --markdown --markdown-output report.md  # Generate markdown with custom output file")
+	fmt.Println("  ./main --merge a.json b.json -o combined.json  # Merge evidence files")
 }
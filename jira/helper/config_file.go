@@ -0,0 +1,111 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"jira-helper/evidence"
+)
+
+// FileConfig holds the settings that can be committed to a --config file.
+// Credentials are deliberately not supported here; keep them in environment variables.
+type FileConfig struct {
+	JIRAURL         string            `yaml:"jira_url" toml:"jira_url"`
+	JIRAUsername    string            `yaml:"jira_username" toml:"jira_username"`
+	JIRAIDRegex     string            `yaml:"jira_id_regex" toml:"jira_id_regex"`
+	KeyPattern      string            `yaml:"key_pattern" toml:"key_pattern"`
+	IgnoreCase      bool              `yaml:"ignore_case" toml:"ignore_case"`
+	DefaultHead     bool              `yaml:"default_head" toml:"default_head"`
+	OutputFile      string            `yaml:"output_file" toml:"output_file"`
+	Compact         bool              `yaml:"compact" toml:"compact"`
+	Checksum        bool              `yaml:"checksum" toml:"checksum"`
+	MergeStrategy   string            `yaml:"merge_strategy" toml:"merge_strategy"`
+	IncludeSprints  bool              `yaml:"include_sprints" toml:"include_sprints"`
+	IncludeUserIDs  bool              `yaml:"include_user_ids" toml:"include_user_ids"`
+	IncludeLinks    bool              `yaml:"include_links" toml:"include_links"`
+	IncludeSubtasks bool              `yaml:"include_subtasks" toml:"include_subtasks"`
+	MaxDescription  int               `yaml:"max_description" toml:"max_description"`
+	Anonymize       bool              `yaml:"anonymize" toml:"anonymize"`
+	ProxyURL        string            `yaml:"proxy" toml:"proxy"`
+	Insecure        bool              `yaml:"insecure" toml:"insecure"`
+	CACertFile      string            `yaml:"ca_cert" toml:"ca_cert"`
+	DateFormat      string            `yaml:"date_format" toml:"date_format"`
+	SortBy          string            `yaml:"sort_by" toml:"sort_by"`
+	GroupBy         string            `yaml:"group_by" toml:"group_by"`
+	TOC             bool              `yaml:"toc" toml:"toc"`
+	LinkTemplate    string            `yaml:"link_template" toml:"link_template"`
+	Timezone        string            `yaml:"timezone" toml:"timezone"`
+	KeyAliases      []string          `yaml:"key_aliases" toml:"key_aliases"`
+	Headers         []string          `yaml:"headers" toml:"headers"`
+	IgnoreList      []string          `yaml:"ignore_list" toml:"ignore_list"`
+	ProjectRegexes  map[string]string `yaml:"project_regexes" toml:"project_regexes"`
+	StatusMap       map[string]string `yaml:"status_map" toml:"status_map"`
+	BranchRules     []BranchRule      `yaml:"branch_rules" toml:"branch_rules"`
+}
+
+// BranchRule selects the JIRA ID regex and project regexes used for branches whose name matches
+// the Branch glob (path.Match syntax, e.g. "hotfix/*"), instead of the file's top-level ones
+type BranchRule struct {
+	Branch         string            `yaml:"branch" toml:"branch"`
+	JIRAIDRegex    string            `yaml:"jira_id_regex" toml:"jira_id_regex"`
+	KeyPattern     string            `yaml:"key_pattern" toml:"key_pattern"`
+	ProjectRegexes map[string]string `yaml:"project_regexes" toml:"project_regexes"`
+}
+
+// LoadConfigFile reads a YAML (.yaml, .yml) or TOML (.toml) config file.
+// Unknown keys are reported as warnings so older binaries keep working with newer files.
+func LoadConfigFile(path string) (*FileConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %w", err)
+	}
+
+	var unmarshal func([]byte, interface{}) error
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		unmarshal = yaml.Unmarshal
+	case ".toml":
+		unmarshal = toml.Unmarshal
+	default:
+		return nil, &evidence.ValidationError{Field: "config", Value: path, Err: fmt.Errorf("must be a .yaml, .yml or .toml file")}
+	}
+
+	var raw map[string]interface{}
+	if err := unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	for _, key := range unknownConfigKeys(raw) {
+		evidence.PrintWarning("Unknown key '%s' in config file %s", key, path)
+	}
+
+	fileConfig := &FileConfig{}
+	if err := unmarshal(data, fileConfig); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+	return fileConfig, nil
+}
+
+// unknownConfigKeys returns the sorted top-level keys that don't map to a FileConfig field
+func unknownConfigKeys(raw map[string]interface{}) []string {
+	known := make(map[string]bool)
+	fileConfigType := reflect.TypeOf(FileConfig{})
+	for i := 0; i < fileConfigType.NumField(); i++ {
+		known[fileConfigType.Field(i).Tag.Get("yaml")] = true
+	}
+
+	var unknown []string
+	for key := range raw {
+		if !known[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadConfigFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name            string
+		fileName        string
+		content         string
+		expected        *FileConfig
+		expectError     bool
+		errorContains   string
+		expectedWarning string
+	}{
+		{
+			name:     "YAML file",
+			fileName: "config.yaml",
+			content: `jira_url: https://example.atlassian.net
+jira_username: ci@example.com
+jira_id_regex: "EV-[0-9]+"
+output_file: evidence.json
+include_sprints: true
+timezone: UTC
+key_aliases:
+  - OLD=EV
+project_regexes:
+  ops: "OPS#[0-9]+"
+status_map:
+  "In QA": QA
+`,
+			expected: &FileConfig{
+				JIRAURL:        "https://example.atlassian.net",
+				JIRAUsername:   "ci@example.com",
+				JIRAIDRegex:    "EV-[0-9]+",
+				OutputFile:     "evidence.json",
+				IncludeSprints: true,
+				Timezone:       "UTC",
+				KeyAliases:     []string{"OLD=EV"},
+				ProjectRegexes: map[string]string{"ops": "OPS#[0-9]+"},
+				StatusMap:      map[string]string{"In QA": "QA"},
+			},
+		},
+		{
+			name:     "TOML file",
+			fileName: "config.toml",
+			content: `jira_url = "https://example.atlassian.net"
+merge_strategy = "first"
+insecure = true
+ca_cert = "/etc/ssl/ca.pem"
+date_format = "date"
+ignore_list = ["UTF", "SHA"]
+
+[project_regexes]
+legacy = "LEG_[0-9]{4}"
+
+[[branch_rules]]
+branch = "hotfix/*"
+jira_id_regex = "SUP-[0-9]+"
+
+[[branch_rules]]
+branch = "feature/*"
+key_pattern = "jira-standard"
+`,
+			expected: &FileConfig{
+				JIRAURL:        "https://example.atlassian.net",
+				MergeStrategy:  "first",
+				Insecure:       true,
+				CACertFile:     "/etc/ssl/ca.pem",
+				DateFormat:     "date",
+				IgnoreList:     []string{"UTF", "SHA"},
+				ProjectRegexes: map[string]string{"legacy": "LEG_[0-9]{4}"},
+				BranchRules: []BranchRule{
+					{Branch: "hotfix/*", JIRAIDRegex: "SUP-[0-9]+"},
+					{Branch: "feature/*", KeyPattern: "jira-standard"},
+				},
+			},
+		},
+		{
+			name:     "Unknown keys warn but don't fail",
+			fileName: "unknown.yml",
+			content: `output_file: evidence.json
+concurrency: 8
+`,
+			expected:        &FileConfig{OutputFile: "evidence.json"},
+			expectedWarning: "Unknown key 'concurrency'",
+		},
+		{
+			name:          "Unsupported extension",
+			fileName:      "config.json",
+			content:       `{}`,
+			expectError:   true,
+			errorContains: "must be a .yaml, .yml or .toml file",
+		},
+		{
+			name:          "Invalid YAML",
+			fileName:      "broken.yaml",
+			content:       "jira_url: [unclosed\n",
+			expectError:   true,
+			errorContains: "error parsing config file",
+		},
+		{
+			name:          "Wrong value type",
+			fileName:      "types.toml",
+			content:       `include_sprints = "yes"`,
+			expectError:   true,
+			errorContains: "error parsing config file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, tt.fileName)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0644))
+
+			// Capture stderr for warnings
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			fileConfig, err := LoadConfigFile(path)
+
+			w.Close()
+			os.Stderr = oldStderr
+			stderrOutput, _ := io.ReadAll(r)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, fileConfig)
+			if tt.expectedWarning != "" {
+				assert.Contains(t, string(stderrOutput), tt.expectedWarning)
+			} else {
+				assert.Empty(t, string(stderrOutput))
+			}
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadConfigFile(filepath.Join(tempDir, "missing.yaml"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error reading config file")
+	})
+}
+
+func TestLoadConfigWithConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jira-helper.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`jira_url: https://file.atlassian.net
+jira_username: file-user@example.com
+jira_id_regex: "FILE-[0-9]+"
+output_file: file.json
+proxy: http://file-proxy:3128
+`), 0644))
+
+	for _, name := range []string{"JIRA_API_TOKEN", "JIRA_URL", "JIRA_USERNAME", "JIRA_ID_REGEX", "OUTPUT_FILE", "HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+		t.Setenv(name, "")
+	}
+
+	t.Run("Config file fills in unset values", func(t *testing.T) {
+		t.Setenv("JIRA_API_TOKEN", "token")
+
+		config, err := LoadConfig(&FlagConfig{ConfigFile: path}, []string{"abc123"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://file.atlassian.net", config.JIRAURL)
+		assert.Equal(t, "file-user@example.com", config.JIRAUsername)
+		assert.Equal(t, "FILE-[0-9]+", config.JIRAIDRegex)
+		assert.Equal(t, "file.json", config.OutputFile)
+		assert.Equal(t, "http://file-proxy:3128", config.ProxyURL)
+	})
+
+	t.Run("Environment and flags take precedence", func(t *testing.T) {
+		t.Setenv("JIRA_API_TOKEN", "token")
+		t.Setenv("JIRA_URL", "https://env.atlassian.net")
+		t.Setenv("JIRA_ID_REGEX", "ENV-[0-9]+")
+		t.Setenv("HTTPS_PROXY", "http://env-proxy:3128")
+
+		config, err := LoadConfig(&FlagConfig{ConfigFile: path, OutputFile: "flag.json"}, []string{"abc123"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "https://env.atlassian.net", config.JIRAURL)
+		assert.Equal(t, "file-user@example.com", config.JIRAUsername)
+		assert.Equal(t, "ENV-[0-9]+", config.JIRAIDRegex)
+		assert.Equal(t, "flag.json", config.OutputFile)
+		assert.Empty(t, config.ProxyURL, "proxy environment variables win over the config file")
+	})
+
+	t.Run("Environment variables are expanded", func(t *testing.T) {
+		t.Setenv("CI_JOB_ID", "4711")
+		t.Setenv("TICKET_PROJECT", "OPS")
+		expandPath := filepath.Join(t.TempDir(), "expand.yaml")
+		require.NoError(t, os.WriteFile(expandPath, []byte(`jira_id_regex: "${TICKET_PROJECT}-[0-9]+$"
+output_file: "evidence/${CI_JOB_ID}-$$.json"
+`), 0644))
+
+		config, err := LoadConfig(&FlagConfig{ConfigFile: expandPath, ExtractOnly: true}, []string{"abc123"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "OPS-[0-9]+$", config.JIRAIDRegex)
+		assert.Equal(t, "evidence/4711-$.json", config.OutputFile)
+	})
+
+	t.Run("Project regexes", func(t *testing.T) {
+		regexPath := filepath.Join(t.TempDir(), "regexes.yaml")
+		require.NoError(t, os.WriteFile(regexPath, []byte("project_regexes:\n  ops: \"OPS#[0-9]+\"\n"), 0644))
+
+		config, err := LoadConfig(&FlagConfig{ConfigFile: regexPath, ExtractOnly: true}, []string{"abc123"})
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"ops": "OPS#[0-9]+"}, config.ProjectRegexes)
+		assert.Equal(t, DefaultJIRAIDRegex, config.JIRAIDRegex, "the primary regex is kept")
+	})
+
+	t.Run("Key pattern preset", func(t *testing.T) {
+		presetPath := filepath.Join(t.TempDir(), "preset.yaml")
+		require.NoError(t, os.WriteFile(presetPath, []byte("key_pattern: jira-standard\n"), 0644))
+
+		config, err := LoadConfig(&FlagConfig{ConfigFile: presetPath, ExtractOnly: true}, []string{"abc123"})
+		require.NoError(t, err)
+		assert.Equal(t, "[A-Z][A-Z0-9]+-[0-9]+", config.JIRAIDRegex)
+
+		// -r wins over the config file, like it does over jira_id_regex
+		config, err = LoadConfig(&FlagConfig{ConfigFile: presetPath, ExtractOnly: true, JIRAIDRegex: "EV-[0-9]+"}, []string{"abc123"})
+		require.NoError(t, err)
+		assert.Equal(t, "EV-[0-9]+", config.JIRAIDRegex)
+
+		bothPath := filepath.Join(t.TempDir(), "both.yaml")
+		require.NoError(t, os.WriteFile(bothPath, []byte("key_pattern: jira-standard\njira_id_regex: \"EV-[0-9]+\"\n"), 0644))
+		_, err = LoadConfig(&FlagConfig{ConfigFile: bothPath, ExtractOnly: true}, []string{"abc123"})
+		assert.ErrorContains(t, err, "key_pattern")
+	})
+
+	t.Run("Invalid project regex", func(t *testing.T) {
+		regexPath := filepath.Join(t.TempDir(), "regexes.toml")
+		require.NoError(t, os.WriteFile(regexPath, []byte("[project_regexes]\nops = \"OPS#[0-9+\"\n"), 0644))
+
+		_, err := LoadConfig(&FlagConfig{ConfigFile: regexPath, ExtractOnly: true}, []string{"abc123"})
+
+		assert.ErrorContains(t, err, "project_regexes.ops")
+	})
+
+	t.Run("Invalid config file", func(t *testing.T) {
+		_, err := LoadConfig(&FlagConfig{ConfigFile: filepath.Join(t.TempDir(), "missing.toml"), ExtractOnly: true}, []string{})
+		assert.Error(t, err)
+	})
+}
@@ -3,11 +3,15 @@ package main
 import (
 	"bytes"
 	"flag"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+
+	"jira-helper/evidence"
 )
 
 func TestParseFlagsComplete(t *testing.T) {
@@ -73,6 +77,31 @@ func TestParseFlagsComplete(t *testing.T) {
 			},
 			expectedArgs: []string{},
 		},
+		{
+			name: "Parse from and to refs",
+			args: []string{"cmd", "--from", "v1.0.0", "--to", "v1.1.0"},
+			expectedFlags: &FlagConfig{
+				FromRef: "v1.0.0",
+				ToRef:   "v1.1.0",
+			},
+			expectedArgs: []string{},
+		},
+		{
+			name: "Parse repeated key aliases",
+			args: []string{"cmd", "--key-alias", "OLD=NEW", "--key-alias", "LEGACY=EV", "EV-1"},
+			expectedFlags: &FlagConfig{
+				KeyAliases: []string{"OLD=NEW", "LEGACY=EV"},
+			},
+			expectedArgs: []string{"EV-1"},
+		},
+		{
+			name: "Parse repeated headers",
+			args: []string{"cmd", "--header", "X-Atlassian-Token: no-check", "--header", "X-Gateway-Key: abc", "EV-1"},
+			expectedFlags: &FlagConfig{
+				Headers: []string{"X-Atlassian-Token: no-check", "X-Gateway-Key: abc"},
+			},
+			expectedArgs: []string{"EV-1"},
+		},
 		{
 			name:          "No flags, only arguments",
 			args:          []string{"cmd", "EV-123", "EV-456"},
@@ -108,6 +137,10 @@ func TestParseFlagsComplete(t *testing.T) {
 			assert.Equal(t, tt.expectedFlags.CommitRange, flags.CommitRange)
 			assert.Equal(t, tt.expectedFlags.Help, flags.Help)
 			assert.Equal(t, tt.expectedFlags.HelpLong, flags.HelpLong)
+			assert.Equal(t, tt.expectedFlags.FromRef, flags.FromRef)
+			assert.Equal(t, tt.expectedFlags.ToRef, flags.ToRef)
+			assert.Equal(t, tt.expectedFlags.KeyAliases, flags.KeyAliases)
+			assert.Equal(t, tt.expectedFlags.Headers, flags.Headers)
 			assert.Equal(t, tt.expectedArgs, args)
 		})
 	}
@@ -140,150 +173,1291 @@ func TestLoadConfigComplete(t *testing.T) {
 		errorContains  string
 	}{
 		{
-			name: "Extract-only mode - no JIRA validation",
+			name: "Extract-only mode - no JIRA validation",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				JIRAIDRegex: "TEST-[0-9]+",
+				OutputFile:  "test.json",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  "TEST-[0-9]+",
+				OutputFile:   "test.json",
+				ExtractOnly:  true,
+				SingleCommit: true,
+			},
+		},
+		{
+			name: "Extract-from-git mode - no JIRA validation",
+			flags: &FlagConfig{
+				ExtractFromGit: true,
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:    DefaultJIRAIDRegex,
+				OutputFile:     DefaultOutputFile,
+				ExtractFromGit: true,
+				SingleCommit:   true,
+			},
+		},
+		{
+			name: "Markdown mode - no JIRA validation",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				MarkdownOutput:   "test-report.md",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:    DefaultJIRAIDRegex,
+				OutputFile:     DefaultOutputFile,
+				MarkdownOutput: "test-report.md",
+				SingleCommit:   true,
+			},
+		},
+		{
+			name:  "Full mode with valid JIRA config",
+			flags: &FlagConfig{},
+			args:  []string{},
+			envVars: map[string]string{
+				"JIRA_API_TOKEN": "token123",
+				"JIRA_URL":       "https://example.atlassian.net",
+				"JIRA_USERNAME":  "user@example.com",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAToken:    "token123",
+				JIRAURL:      "https://example.atlassian.net",
+				JIRAUsername: "user@example.com",
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				SingleCommit: true,
+			},
+		},
+		{
+			name:  "Full mode with missing JIRA token",
+			flags: &FlagConfig{},
+			args:  []string{},
+			envVars: map[string]string{
+				"JIRA_API_TOKEN": "",
+				"JIRA_URL":       "https://example.atlassian.net",
+				"JIRA_USERNAME":  "user@example.com",
+			},
+			expectError:   true,
+			errorContains: "JIRA_API_TOKEN",
+		},
+		{
+			name:  "Full mode with missing JIRA URL",
+			flags: &FlagConfig{},
+			args:  []string{},
+			envVars: map[string]string{
+				"JIRA_API_TOKEN": "token123",
+				"JIRA_URL":       "",
+				"JIRA_USERNAME":  "user@example.com",
+			},
+			expectError:   true,
+			errorContains: "JIRA_URL",
+		},
+		{
+			name:  "Full mode with missing JIRA username",
+			flags: &FlagConfig{},
+			args:  []string{},
+			envVars: map[string]string{
+				"JIRA_API_TOKEN": "token123",
+				"JIRA_URL":       "https://example.atlassian.net",
+				"JIRA_USERNAME":  "",
+			},
+			expectError:   true,
+			errorContains: "JIRA_USERNAME",
+		},
+		{
+			name: "Environment variables override defaults",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"JIRA_ID_REGEX": "CUSTOM-[0-9]+",
+				"OUTPUT_FILE":   "custom_output.json",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  "CUSTOM-[0-9]+",
+				OutputFile:   "custom_output.json",
+				ExtractOnly:  true,
+				SingleCommit: true,
+			},
+		},
+		{
+			name: "Flags override environment variables",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				JIRAIDRegex: "FLAG-[0-9]+",
+				OutputFile:  "flag_output.json",
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"JIRA_ID_REGEX": "ENV-[0-9]+",
+				"OUTPUT_FILE":   "env_output.json",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  "FLAG-[0-9]+",
+				OutputFile:   "flag_output.json",
+				ExtractOnly:  true,
+				SingleCommit: true,
+			},
+		},
+		{
+			name: "CommitRange flag sets SingleCommit to false",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				CommitRange: true,
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: false,
+			},
+		},
+		{
+			name: "From and to refs",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				FromRef:     "v1.0.0",
+				ToRef:       "v1.1.0",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				FromRef:      "v1.0.0",
+				ToRef:        "v1.1.0",
+			},
+		},
+		{
+			name: "From ref without to ref",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				FromRef:     "v1.0.0",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "required when --from is set",
+		},
+		{
+			name: "To ref without from ref",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				ToRef:       "v1.1.0",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "required when --to is set",
+		},
+		{
+			name: "Merge mode - no JIRA validation",
+			flags: &FlagConfig{
+				Merge:         true,
+				MergeStrategy: "first",
+			},
+			args:        []string{"a.json", "b.json"},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:   DefaultJIRAIDRegex,
+				OutputFile:    DefaultOutputFile,
+				SingleCommit:  true,
+				MergeStrategy: "first",
+			},
+		},
+		{
+			name: "Invalid log format",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				LogFormat:   "xml",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "log-format",
+		},
+		{
+			name: "Quiet with JSON log format",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Quiet:       true,
+				LogFormat:   "json",
+			},
+			args:        []string{"abc123"},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				Quiet:        true,
+				LogFormat:    evidence.LogFormatJSON,
+			},
+		},
+		{
+			name: "Invalid merge strategy",
+			flags: &FlagConfig{
+				Merge:         true,
+				MergeStrategy: "newest",
+			},
+			args:          []string{"a.json", "b.json"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "merge-strategy",
+		},
+		{
+			name: "Date format preset",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				DateFormat:       "date",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				SingleCommit: true,
+				DateFormat:   "2006-01-02",
+			},
+		},
+		{
+			name: "Invalid date format",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				DateFormat:       "dd/mm/yyyy",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "date-format",
+		},
+		{
+			name: "Use reflog with range",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				CommitRange: true,
+				UseReflog:   true,
+			},
+			args:        []string{"abc123"},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex: DefaultJIRAIDRegex,
+				OutputFile:  DefaultOutputFile,
+				ExtractOnly: true,
+				UseReflog:   true,
+			},
+		},
+		{
+			name: "Use reflog requires range",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				UseReflog:   true,
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "use-reflog",
+		},
+		{
+			name: "Transitions since date",
+			flags: &FlagConfig{
+				ExtractOnly:      true,
+				TransitionsSince: "2024-01-01",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:      DefaultJIRAIDRegex,
+				OutputFile:       DefaultOutputFile,
+				ExtractOnly:      true,
+				SingleCommit:     true,
+				TransitionsSince: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name: "Invalid transitions since date",
+			flags: &FlagConfig{
+				ExtractOnly:      true,
+				TransitionsSince: "01/02/2024",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "transitions-since",
+		},
+		{
+			name: "Split output requires markdown mode",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				SplitOutput: "wiki",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "split-output",
+		},
+		{
+			name: "Split output replaces the markdown output file",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				MarkdownOutput:   "report.md",
+				SplitOutput:      "wiki",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "split-output",
+		},
+		{
+			name: "Group by with split output",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				SplitOutput:      "wiki",
+				GroupBy:          "project",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "group-by",
+		},
+		{
+			name: "Unknown group by",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				GroupBy:          "assignee",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "group-by",
+		},
+		{
+			name: "Commit metadata with range",
+			flags: &FlagConfig{
+				CommitRange:    true,
+				WithCommitMeta: true,
+			},
+			args: []string{"abc123"},
+			envVars: map[string]string{
+				"JIRA_API_TOKEN": "token",
+				"JIRA_URL":       "https://example.atlassian.net",
+				"JIRA_USERNAME":  "user@example.com",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAToken:      "token",
+				JIRAURL:        "https://example.atlassian.net",
+				JIRAUsername:   "user@example.com",
+				JIRAIDRegex:    DefaultJIRAIDRegex,
+				OutputFile:     DefaultOutputFile,
+				WithCommitMeta: true,
+			},
+		},
+		{
+			name: "Commit metadata requires a range",
+			flags: &FlagConfig{
+				ExtractOnly:    true,
+				WithCommitMeta: true,
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "with-commit-meta",
+		},
+		{
+			name: "Link style",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				LinkStyle:   "history",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				LinkTemplate: "{base}/browse/{key}?page=com.atlassian.jira.plugin.system.issuetabpanels:changehistory-tabpanel",
+			},
+		},
+		{
+			name: "Link template without key",
+			flags: &FlagConfig{
+				ExtractOnly:  true,
+				LinkTemplate: "{base}/browse",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "link-template",
+		},
+		{
+			name: "Fallback to range in single-commit mode",
+			flags: &FlagConfig{
+				ExtractOnly:     true,
+				FallbackToRange: true,
+			},
+			args:        []string{"abc123"},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:     DefaultJIRAIDRegex,
+				OutputFile:      DefaultOutputFile,
+				ExtractOnly:     true,
+				SingleCommit:    true,
+				FallbackToRange: true,
+			},
+		},
+		{
+			name: "Fallback to range rejects range mode",
+			flags: &FlagConfig{
+				ExtractOnly:     true,
+				CommitRange:     true,
+				FallbackToRange: true,
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "fallback-to-range",
+		},
+		{
+			name: "Output formats and table of contents",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Format:      "json, Markdown,html,md,TEXT",
+				TOC:         true,
+			},
+			args:        []string{"abc123"},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				Formats:      []string{FormatJSON, FormatMarkdown, FormatHTML, FormatText},
+				TOC:          true,
+				ExtractOnly:  true,
+				SingleCommit: true,
+			},
+		},
+		{
+			name: "Included and excluded statuses",
+			flags: &FlagConfig{
+				ExtractOnly:   true,
+				IncludeStatus: "In Review,QA",
+				ExcludeStatus: "Done, Won't Do,,",
+			},
+			args:        []string{"abc123"},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:     DefaultJIRAIDRegex,
+				OutputFile:      DefaultOutputFile,
+				IncludeStatuses: []string{"In Review", "QA"},
+				ExcludeStatuses: []string{"Done", "Won't Do"},
+				ExtractOnly:     true,
+				SingleCommit:    true,
+			},
+		},
+		{
+			name: "Commits file replaces the start commit",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				CommitsFile: "approved.txt",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				CommitsFile:  "approved.txt",
+			},
+		},
+		{
+			name: "Commits file with a range",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				CommitRange: true,
+				CommitsFile: "approved.txt",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "commits-file",
+		},
+		{
+			name: "Invalid output format",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Format:      "json,pdf",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "format",
+		},
+		{
+			name: "Append requires the json format",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Append:      true,
+				Format:      "md",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "append",
+		},
+		{
+			name: "Checksum requires the json format",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Checksum:    true,
+				Format:      "md",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "checksum",
+		},
+		{
+			name: "Sort by is normalized",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				SortBy:           "Priority",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				SingleCommit: true,
+				SortBy:       "priority",
+			},
+		},
+		{
+			name: "Invalid sort by",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				SortBy:           "assignee",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "sort-by",
+		},
+		{
+			name: "JQL mode",
+			flags: &FlagConfig{
+				JQL: "fixVersion = 1.2.3",
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"JIRA_API_TOKEN": "token",
+				"JIRA_URL":       "https://example.atlassian.net",
+				"JIRA_USERNAME":  "user@example.com",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAToken:    "token",
+				JIRAURL:      "https://example.atlassian.net",
+				JIRAUsername: "user@example.com",
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				SingleCommit: true,
+				JQL:          "fixVersion = 1.2.3",
+			},
+		},
+		{
+			name: "JQL combined with a commit argument",
+			flags: &FlagConfig{
+				JQL: "fixVersion = 1.2.3",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "jql",
+		},
+		{
+			name: "JQL combined with a ref range",
+			flags: &FlagConfig{
+				JQL:     "project = EV",
+				FromRef: "v1.0.0",
+				ToRef:   "v1.1.0",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "cannot be combined",
+		},
+		{
+			name: "List statuses",
+			flags: &FlagConfig{
+				ListStatuses: " EV ",
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"JIRA_API_TOKEN": "token",
+				"JIRA_URL":       "https://example.atlassian.net",
+				"JIRA_USERNAME":  "user@example.com",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAToken:    "token",
+				JIRAURL:      "https://example.atlassian.net",
+				JIRAUsername: "user@example.com",
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				SingleCommit: true,
+				ListStatuses: "EV",
+			},
+		},
+		{
+			name: "List statuses without a project key",
+			flags: &FlagConfig{
+				ListStatuses: " ",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "list-statuses",
+		},
+		{
+			name: "List statuses combined with JQL",
+			flags: &FlagConfig{
+				ListStatuses: "EV",
+				JQL:          "project = EV",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "cannot be combined",
+		},
+		{
+			name: "Timezone",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				Timezone:         "UTC",
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				SingleCommit: true,
+				Location:     time.UTC,
+			},
+		},
+		{
+			name: "Unknown timezone",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				Timezone:         "Mars/Olympus_Mons",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "timezone",
+		},
+		{
+			name: "TLS settings from environment",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"JIRA_CA_CERT":              "/etc/ssl/internal-ca.pem",
+				"JIRA_INSECURE_SKIP_VERIFY": "true",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:        DefaultJIRAIDRegex,
+				OutputFile:         DefaultOutputFile,
+				ExtractOnly:        true,
+				SingleCommit:       true,
+				CACertFile:         "/etc/ssl/internal-ca.pem",
+				InsecureSkipVerify: true,
+			},
+		},
+		{
+			name: "User-agent from environment",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"JIRA_USER_AGENT": "release-pipeline/1.0",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				UserAgent:    "release-pipeline/1.0",
+			},
+		},
+		{
+			name: "User-agent flag overrides environment",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				UserAgent:   "nightly-audit",
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"JIRA_USER_AGENT": "release-pipeline/1.0",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				UserAgent:    "nightly-audit",
+			},
+		},
+		{
+			name: "Post URL with auth from environment",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				PostURL:     "https://evidence.internal/api",
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"EVIDENCE_POST_AUTH": "Bearer secret",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				PostURL:      "https://evidence.internal/api",
+				PostAuth:     "Bearer secret",
+			},
+		},
+		{
+			name: "Invalid post URL",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				PostURL:     "evidence.internal/api",
+			},
+			args:          []string{},
+			expectError:   true,
+			errorContains: "post-url",
+		},
+		{
+			name: "Git path from environment",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"GIT_BINARY": "/opt/git/bin/git",
+			},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				GitPath:      "/opt/git/bin/git",
+			},
+		},
+		{
+			name: "Invalid JIRA_INSECURE_SKIP_VERIFY value",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+			},
+			args: []string{},
+			envVars: map[string]string{
+				"JIRA_INSECURE_SKIP_VERIFY": "sometimes",
+			},
+			expectError:   true,
+			errorContains: "JIRA_INSECURE_SKIP_VERIFY",
+		},
+		{
+			name: "Insecure flag",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Insecure:    true,
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:        DefaultJIRAIDRegex,
+				OutputFile:         DefaultOutputFile,
+				ExtractOnly:        true,
+				SingleCommit:       true,
+				InsecureSkipVerify: true,
+			},
+		},
+		{
+			name: "Watch mode with interval",
+			flags: &FlagConfig{
+				ExtractOnly:   true,
+				Watch:         true,
+				WatchInterval: 5 * time.Second,
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:   DefaultJIRAIDRegex,
+				OutputFile:    DefaultOutputFile,
+				ExtractOnly:   true,
+				SingleCommit:  true,
+				Watch:         true,
+				WatchInterval: 5 * time.Second,
+			},
+		},
+		{
+			name: "Ignore list is split and normalized",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				IgnoreList:  "UTF, sha,,ISO,RFC",
+			},
+			args:    []string{},
+			envVars: map[string]string{},
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				IgnoreKeys:   []string{"UTF", "SHA", "ISO", "RFC"},
+			},
+		},
+		{
+			name: "Ignore list with an invalid key",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				IgnoreList:  "UTF-8",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "ignore-list",
+		},
+		{
+			name: "Key aliases are normalized to upper case",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				KeyAliases:  []string{"old=new", "LEGACY = EV"},
+			},
+			args:    []string{},
+			envVars: map[string]string{},
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
+				SingleCommit: true,
+				KeyAliases:   map[string]string{"OLD": "NEW", "LEGACY": "EV"},
+			},
+		},
+		{
+			name: "Key alias without separator",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				KeyAliases:  []string{"OLD"},
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "key-alias",
+		},
+		{
+			name: "Key alias to itself",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				KeyAliases:  []string{"EV=ev"},
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "must differ",
+		},
+		{
+			name: "Continue on error with a commits file",
+			flags: &FlagConfig{
+				ExtractOnly:     true,
+				CommitsFile:     "commits.txt",
+				ContinueOnError: true,
+			},
+			args:    []string{},
+			envVars: map[string]string{},
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:     DefaultJIRAIDRegex,
+				OutputFile:      DefaultOutputFile,
+				ExtractOnly:     true,
+				SingleCommit:    true,
+				CommitsFile:     "commits.txt",
+				ContinueOnError: true,
+			},
+		},
+		{
+			name: "Continue on error without a commits file",
+			flags: &FlagConfig{
+				ExtractOnly:     true,
+				ContinueOnError: true,
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "continue-on-error",
+		},
+		{
+			name: "Only errors with a status filter",
+			flags: &FlagConfig{
+				ExtractOnly:   true,
+				OnlyErrors:    true,
+				ExcludeStatus: "Done",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "only-errors",
+		},
+		{
+			name: "If newer with extract only",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				IfNewer:     true,
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "if-newer",
+		},
+		{
+			name: "If newer with markdown mode",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				IfNewer:          true,
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "if-newer",
+		},
+		{
+			name: "Scan file with commit arguments",
+			flags: &FlagConfig{
+				ScanFile: "pr_body.md",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "scan-file",
+		},
+		{
+			name: "Scan file with JQL",
+			flags: &FlagConfig{
+				ScanFile: "pr_body.md",
+				JQL:      "project = EV",
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "scan-file",
+		},
+		{
+			name: "Transitions CSV with extract only",
+			flags: &FlagConfig{
+				ExtractOnly:    true,
+				TransitionsCSV: "transitions.csv",
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "transitions-csv",
+		},
+		{
+			name: "Negative max description",
+			flags: &FlagConfig{
+				ExtractOnly:    true,
+				MaxDescription: -1,
+			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "max-description",
+		},
+		{
+			name: "Key pattern preset",
 			flags: &FlagConfig{
 				ExtractOnly: true,
-				JIRAIDRegex: "TEST-[0-9]+",
-				OutputFile:  "test.json",
+				KeyPattern:  "Jira-Standard",
 			},
-			args:        []string{},
-			envVars:     map[string]string{},
-			expectError: false,
+			args:    []string{},
+			envVars: map[string]string{},
 			expectedConfig: &AppConfig{
-				JIRAIDRegex:  "TEST-[0-9]+",
-				OutputFile:   "test.json",
+				JIRAIDRegex:  "[A-Z][A-Z0-9]+-[0-9]+",
+				OutputFile:   DefaultOutputFile,
 				ExtractOnly:  true,
 				SingleCommit: true,
 			},
 		},
 		{
-			name: "Extract-from-git mode - no JIRA validation",
+			name: "Unknown key pattern",
 			flags: &FlagConfig{
-				ExtractFromGit: true,
+				ExtractOnly: true,
+				KeyPattern:  "strict",
 			},
-			args:        []string{},
-			envVars:     map[string]string{},
-			expectError: false,
-			expectedConfig: &AppConfig{
-				JIRAIDRegex:    DefaultJIRAIDRegex,
-				OutputFile:     DefaultOutputFile,
-				ExtractFromGit: true,
-				SingleCommit:   true,
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "default, jira-standard, jira-underscore",
+		},
+		{
+			name: "Key pattern combined with a regex",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				KeyPattern:  "jira-standard",
+				JIRAIDRegex: "EV-[0-9]+",
 			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "cannot be combined with -r",
 		},
 		{
-			name: "Markdown mode - no JIRA validation",
+			name: "Repeated headers",
 			flags: &FlagConfig{
-				GenerateMarkdown: true,
-				MarkdownOutput:   "test-report.md",
+				ExtractOnly: true,
+				Headers:     []string{"X-Atlassian-Token: no-check", "x-gateway-key:  abc:123 ", "X-Atlassian-Token: second"},
 			},
-			args:        []string{},
-			envVars:     map[string]string{},
-			expectError: false,
+			args:    []string{},
+			envVars: map[string]string{},
 			expectedConfig: &AppConfig{
 				JIRAIDRegex:  DefaultJIRAIDRegex,
 				OutputFile:   DefaultOutputFile,
+				ExtractOnly:  true,
 				SingleCommit: true,
+				Headers: http.Header{
+					"X-Atlassian-Token": {"no-check", "second"},
+					"X-Gateway-Key":     {"abc:123"},
+				},
 			},
 		},
 		{
-			name:  "Full mode with valid JIRA config",
-			flags: &FlagConfig{},
-			args:  []string{},
-			envVars: map[string]string{
-				"JIRA_API_TOKEN": "token123",
-				"JIRA_URL":       "https://example.atlassian.net",
-				"JIRA_USERNAME":  "user@example.com",
+			name: "Header without separator",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Headers:     []string{"X-Atlassian-Token no-check"},
 			},
-			expectError: false,
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "header",
+		},
+		{
+			name: "Header with an invalid name",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Headers:     []string{"X Gateway: key"},
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "valid header name",
+		},
+		{
+			name: "Header value with a line break",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Headers:     []string{"X-Gateway: key\r\nX-Injected: 1"},
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "line breaks",
+		},
+		{
+			name: "Header overriding the credentials",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Headers:     []string{"authorization: Bearer abc"},
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "cannot be overridden",
+		},
+		{
+			name: "Base branch implies range mode",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				BaseBranch:  "main",
+			},
+			args:    []string{},
+			envVars: map[string]string{},
 			expectedConfig: &AppConfig{
-				JIRAToken:    "token123",
-				JIRAURL:      "https://example.atlassian.net",
-				JIRAUsername: "user@example.com",
 				JIRAIDRegex:  DefaultJIRAIDRegex,
 				OutputFile:   DefaultOutputFile,
-				SingleCommit: true,
+				ExtractOnly:  true,
+				SingleCommit: false,
+				BaseBranch:   "main",
 			},
 		},
 		{
-			name:  "Full mode with missing JIRA token",
-			flags: &FlagConfig{},
-			args:  []string{},
-			envVars: map[string]string{
-				"JIRA_API_TOKEN": "",
-				"JIRA_URL":       "https://example.atlassian.net",
-				"JIRA_USERNAME":  "user@example.com",
+			name: "Base branch with a start commit",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				BaseBranch:  "main",
 			},
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
 			expectError:   true,
-			errorContains: "JIRA_API_TOKEN",
+			errorContains: "base-branch",
 		},
 		{
-			name:  "Full mode with missing JIRA URL",
-			flags: &FlagConfig{},
-			args:  []string{},
-			envVars: map[string]string{
-				"JIRA_API_TOKEN": "token123",
-				"JIRA_URL":       "",
-				"JIRA_USERNAME":  "user@example.com",
+			name: "Negative watch interval",
+			flags: &FlagConfig{
+				ExtractOnly:   true,
+				Watch:         true,
+				WatchInterval: -time.Second,
 			},
+			args:          []string{},
+			envVars:       map[string]string{},
 			expectError:   true,
-			errorContains: "JIRA_URL",
+			errorContains: "watch-interval",
 		},
 		{
-			name:  "Full mode with missing JIRA username",
-			flags: &FlagConfig{},
-			args:  []string{},
-			envVars: map[string]string{
-				"JIRA_API_TOKEN": "token123",
-				"JIRA_URL":       "https://example.atlassian.net",
-				"JIRA_USERNAME":  "",
+			name: "Max message length",
+			flags: &FlagConfig{
+				ExtractOnly:      true,
+				CommitRange:      true,
+				MaxMessageLength: 1024,
+			},
+			args:        []string{},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectedConfig: &AppConfig{
+				JIRAIDRegex:      DefaultJIRAIDRegex,
+				OutputFile:       DefaultOutputFile,
+				ExtractOnly:      true,
+				SingleCommit:     false,
+				MaxMessageLength: 1024,
 			},
-			expectError:   true,
-			errorContains: "JIRA_USERNAME",
 		},
 		{
-			name: "Environment variables override defaults",
+			name: "Negative max message length",
 			flags: &FlagConfig{
-				ExtractOnly: true,
+				ExtractOnly:      true,
+				MaxMessageLength: -1,
 			},
-			args: []string{},
-			envVars: map[string]string{
-				"JIRA_ID_REGEX": "CUSTOM-[0-9]+",
-				"OUTPUT_FILE":   "custom_output.json",
+			args:          []string{"abc123"},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "max-message-length",
+		},
+		{
+			name: "Connection pool tuning",
+			flags: &FlagConfig{
+				ExtractOnly:     true,
+				MaxIdleConns:    64,
+				IdleConnTimeout: 30 * time.Second,
+				NoKeepAlives:    true,
 			},
+			args:        []string{},
+			envVars:     map[string]string{},
 			expectError: false,
 			expectedConfig: &AppConfig{
-				JIRAIDRegex:  "CUSTOM-[0-9]+",
-				OutputFile:   "custom_output.json",
+				JIRAIDRegex:     DefaultJIRAIDRegex,
+				OutputFile:      DefaultOutputFile,
+				ExtractOnly:     true,
+				SingleCommit:    true,
+				MaxIdleConns:    64,
+				IdleConnTimeout: 30 * time.Second,
+				NoKeepAlives:    true,
+			},
+		},
+		{
+			name: "Negative max idle connections",
+			flags: &FlagConfig{
 				ExtractOnly:  true,
-				SingleCommit: true,
+				MaxIdleConns: -1,
 			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "max-idle-conns",
 		},
 		{
-			name: "Flags override environment variables",
+			name: "Concurrent fetching",
 			flags: &FlagConfig{
 				ExtractOnly: true,
-				JIRAIDRegex: "FLAG-[0-9]+",
-				OutputFile:  "flag_output.json",
-			},
-			args: []string{},
-			envVars: map[string]string{
-				"JIRA_ID_REGEX": "ENV-[0-9]+",
-				"OUTPUT_FILE":   "env_output.json",
+				Workers:     8,
 			},
+			args:        []string{},
+			envVars:     map[string]string{},
 			expectError: false,
 			expectedConfig: &AppConfig{
-				JIRAIDRegex:  "FLAG-[0-9]+",
-				OutputFile:   "flag_output.json",
+				JIRAIDRegex:  DefaultJIRAIDRegex,
+				OutputFile:   DefaultOutputFile,
 				ExtractOnly:  true,
 				SingleCommit: true,
+				Workers:      8,
 			},
 		},
 		{
-			name: "CommitRange flag sets SingleCommit to false",
+			name: "Negative workers",
 			flags: &FlagConfig{
 				ExtractOnly: true,
-				CommitRange: true,
+				Workers:     -1,
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "workers",
+		},
+		{
+			name: "Negative idle connection timeout",
+			flags: &FlagConfig{
+				ExtractOnly:     true,
+				IdleConnTimeout: -time.Second,
+			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "idle-conn-timeout",
+		},
+		{
+			name: "Fields",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Fields:      "key, status,transitions",
 			},
 			args:        []string{},
 			envVars:     map[string]string{},
@@ -292,8 +1466,20 @@ func TestLoadConfigComplete(t *testing.T) {
 				JIRAIDRegex:  DefaultJIRAIDRegex,
 				OutputFile:   DefaultOutputFile,
 				ExtractOnly:  true,
-				SingleCommit: false,
+				SingleCommit: true,
+				Fields:       []string{"key", "status", "transitions"},
+			},
+		},
+		{
+			name: "Unknown field",
+			flags: &FlagConfig{
+				ExtractOnly: true,
+				Fields:      "key,summary",
 			},
+			args:          []string{},
+			envVars:       map[string]string{},
+			expectError:   true,
+			errorContains: "fields",
 		},
 		{
 			name: "All environment variables and flags",
@@ -330,6 +1516,11 @@ func TestLoadConfigComplete(t *testing.T) {
 			os.Unsetenv("JIRA_USERNAME")
 			os.Unsetenv("JIRA_ID_REGEX")
 			os.Unsetenv("OUTPUT_FILE")
+			os.Unsetenv("JIRA_CA_CERT")
+			os.Unsetenv("JIRA_INSECURE_SKIP_VERIFY")
+			os.Unsetenv("JIRA_USER_AGENT")
+			os.Unsetenv("EVIDENCE_POST_AUTH")
+			os.Unsetenv("GIT_BINARY")
 
 			// Set environment variables
 			for key, value := range tt.envVars {
@@ -454,6 +1645,30 @@ func TestGetOrDefaultComplete(t *testing.T) {
 	}
 }
 
+func TestExpandEnv(t *testing.T) {
+	t.Setenv("CI_JOB_ID", "4711")
+	t.Setenv("EVIDENCE_DIR", "/tmp/evidence")
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{"Plain value", "evidence.json", "evidence.json"},
+		{"Braced and bare references", "${EVIDENCE_DIR}/$CI_JOB_ID.json", "/tmp/evidence/4711.json"},
+		{"Unset variable expands to empty", "${NOT_SET_ANYWHERE}evidence.json", "evidence.json"},
+		{"Escaped dollar", "cost$$5", "cost$5"},
+		{"Regex anchors are kept", "^(EV|OPS)-[0-9]+$", "^(EV|OPS)-[0-9]+$"},
+		{"Anchor before a group is kept", "EV-[0-9]+$)", "EV-[0-9]+$)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, expandEnv(tt.value))
+		})
+	}
+}
+
 func TestValidateJIRAConfigComplete(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -538,8 +1753,8 @@ func TestValidateJIRAConfigComplete(t *testing.T) {
 			err := validateJIRAConfig(tt.config)
 			if tt.expectError {
 				assert.Error(t, err)
-				validationErr, ok := err.(*ValidationError)
-				assert.True(t, ok, "Error should be ValidationError type")
+				validationErr, ok := err.(*evidence.ValidationError)
+				assert.True(t, ok, "Error should be evidence.ValidationError type")
 				assert.Equal(t, tt.expectedField, validationErr.Field)
 				assert.Contains(t, validationErr.Error(), tt.errorMessage)
 			} else {
@@ -548,3 +1763,140 @@ func TestValidateJIRAConfigComplete(t *testing.T) {
 		})
 	}
 }
+
+func TestParseStatusMap(t *testing.T) {
+	tests := []struct {
+		name          string
+		fileEntries   map[string]string
+		value         string
+		expected      map[string]string
+		errorContains string
+	}{
+		{name: "Nothing", expected: map[string]string{}},
+		{
+			name:     "Flag entries",
+			value:    " In QA = QA ,QA in Progress=QA,",
+			expected: map[string]string{"in qa": "QA", "qa in progress": "QA"},
+		},
+		{
+			name:        "Flag entries override file entries case-insensitively",
+			fileEntries: map[string]string{"In QA": "Testing", "Closed": "Done"},
+			value:       "IN QA=QA",
+			expected:    map[string]string{"in qa": "QA", "closed": "Done"},
+		},
+		{
+			name:        "File statuses may contain commas and equals signs",
+			fileEntries: map[string]string{"Review, then QA": "QA", "A=B": "AB"},
+			expected:    map[string]string{"review, then qa": "QA", "a=b": "AB"},
+		},
+		{name: "Missing target", value: "In QA", errorContains: "must be FROM=TO"},
+		{name: "Empty source", value: "=QA", errorContains: "must be FROM=TO"},
+		{name: "Empty file target", fileEntries: map[string]string{"In QA": " "}, errorContains: "must be FROM=TO"},
+		{name: "Mapping to the error status", value: "Blocked=error", errorContains: "failed to fetch"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusMap, err := parseStatusMap(tt.fileEntries, tt.value)
+			if tt.errorContains != "" {
+				var validationErr *evidence.ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, statusMap)
+		})
+	}
+}
+
+func TestResolveBranchRules(t *testing.T) {
+	tests := []struct {
+		name          string
+		rules         []BranchRule
+		regexGiven    bool
+		expected      []BranchRule
+		errorContains string
+	}{
+		{name: "No rules"},
+		{
+			name: "Key patterns are resolved",
+			rules: []BranchRule{
+				{Branch: "hotfix/*", JIRAIDRegex: "SUP-[0-9]+"},
+				{Branch: "feature/*", KeyPattern: "jira-standard", ProjectRegexes: map[string]string{"ops": "OPS#[0-9]+"}},
+			},
+			expected: []BranchRule{
+				{Branch: "hotfix/*", JIRAIDRegex: "SUP-[0-9]+"},
+				{Branch: "feature/*", JIRAIDRegex: "[A-Z][A-Z0-9]+-[0-9]+", ProjectRegexes: map[string]string{"ops": "OPS#[0-9]+"}},
+			},
+		},
+		{
+			name:       "A given regex replaces the rules' regexes",
+			rules:      []BranchRule{{Branch: "hotfix/*", JIRAIDRegex: "SUP-[0-9]+", ProjectRegexes: map[string]string{"ops": "OPS#[0-9]+"}}},
+			regexGiven: true,
+			expected:   []BranchRule{{Branch: "hotfix/*", ProjectRegexes: map[string]string{"ops": "OPS#[0-9]+"}}},
+		},
+		{name: "Missing branch", rules: []BranchRule{{JIRAIDRegex: "SUP-[0-9]+"}}, errorContains: "needs a branch pattern"},
+		{name: "Invalid branch pattern", rules: []BranchRule{{Branch: "release/[", JIRAIDRegex: "SUP-[0-9]+"}}, errorContains: "invalid branch pattern"},
+		{name: "Rule without settings", rules: []BranchRule{{Branch: "hotfix/*"}}, errorContains: "sets none of"},
+		{name: "Key pattern and regex", rules: []BranchRule{{Branch: "hotfix/*", JIRAIDRegex: "SUP-[0-9]+", KeyPattern: "default"}}, errorContains: "cannot be combined"},
+		{name: "Unknown key pattern", rules: []BranchRule{{Branch: "hotfix/*", KeyPattern: "jira"}}, errorContains: "must be one of"},
+		{name: "Invalid regex", rules: []BranchRule{{Branch: "hotfix/*", JIRAIDRegex: "SUP-[0-9+"}}, errorContains: "jira_id_regex"},
+		{name: "Invalid project regex", rules: []BranchRule{{Branch: "hotfix/*", ProjectRegexes: map[string]string{"ops": "OPS#[0-9+"}}}, errorContains: "project_regexes.ops"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rules, err := resolveBranchRules(tt.rules, DefaultJIRAIDRegex, tt.regexGiven)
+			if tt.errorContains != "" {
+				assert.ErrorContains(t, err, tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, rules)
+		})
+	}
+}
+
+func TestAppConfigForBranch(t *testing.T) {
+	config := &AppConfig{
+		JIRAIDRegex:    "EV-[0-9]+",
+		ProjectRegexes: map[string]string{"legacy": "LEG_[0-9]{4}"},
+		BranchRules: []BranchRule{
+			{Branch: "hotfix/*", JIRAIDRegex: "SUP-[0-9]+"},
+			{Branch: "hotfix/urgent-*", JIRAIDRegex: "URG-[0-9]+"},
+			{Branch: "feature/*", ProjectRegexes: map[string]string{"ops": "OPS#[0-9]+"}},
+		},
+	}
+
+	tests := []struct {
+		name                   string
+		branch                 string
+		expectedRule           string
+		expectedRegex          string
+		expectedProjectRegexes map[string]string
+	}{
+		{"Regex rule keeps the project regexes", "hotfix/login", "hotfix/*", "SUP-[0-9]+", map[string]string{"legacy": "LEG_[0-9]{4}"}},
+		{"The first matching rule wins", "hotfix/urgent-fix", "hotfix/*", "SUP-[0-9]+", map[string]string{"legacy": "LEG_[0-9]{4}"}},
+		{"Project regex rule keeps the regex", "feature/search", "feature/*", "EV-[0-9]+", map[string]string{"ops": "OPS#[0-9]+"}},
+		{"Star doesn't match a slash", "feature/search/v2", "", "EV-[0-9]+", map[string]string{"legacy": "LEG_[0-9]{4}"}},
+		{"No matching rule", "main", "", "EV-[0-9]+", map[string]string{"legacy": "LEG_[0-9]{4}"}},
+		{"Detached HEAD", "", "", "EV-[0-9]+", map[string]string{"legacy": "LEG_[0-9]{4}"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			branchConfig, rule := config.forBranch(tt.branch)
+			if tt.expectedRule == "" {
+				assert.Nil(t, rule)
+				assert.Same(t, config, branchConfig)
+			} else {
+				assert.Equal(t, tt.expectedRule, rule.Branch)
+			}
+			assert.Equal(t, tt.expectedRegex, branchConfig.JIRAIDRegex)
+			assert.Equal(t, tt.expectedProjectRegexes, branchConfig.ProjectRegexes)
+		})
+	}
+
+	assert.Equal(t, "EV-[0-9]+", config.JIRAIDRegex, "the configuration is left untouched")
+}
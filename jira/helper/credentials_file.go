@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"jira-helper/evidence"
+)
+
+// Credentials holds the JIRA credentials read from a --credentials-file
+type Credentials struct {
+	Token    string
+	URL      string
+	Username string
+}
+
+// LoadCredentialsFile reads JIRA credentials from a file in the same KEY=VALUE format as the
+// .env template (JIRA_API_TOKEN, JIRA_URL, JIRA_USERNAME). Blank lines, # comments and an
+// "export " prefix are allowed.
+//
+// The file must not be writable by group or others, since anyone who can edit it can redirect
+// requests and capture the token. A file readable by others is accepted with a warning.
+func LoadCredentialsFile(path string) (*Credentials, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading credentials file: %w", err)
+	}
+	if perm := info.Mode().Perm(); perm&0022 != 0 {
+		return nil, &evidence.ValidationError{Field: "credentials-file", Value: path,
+			Err: fmt.Errorf("is writable by other users (permissions %#o), run: chmod 600 %s", perm, path)}
+	} else if perm&0044 != 0 {
+		evidence.PrintWarning("Credentials file %s is readable by other users (permissions %#o), run: chmod 600 %s", path, perm, path)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading credentials file: %w", err)
+	}
+
+	credentials := &Credentials{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(strings.TrimPrefix(line, "export "), "=")
+		if !found {
+			return nil, fmt.Errorf("error parsing credentials file %s: line %d is not KEY=VALUE", path, lineNumber)
+		}
+		key, value = strings.TrimSpace(key), unquote(strings.TrimSpace(value))
+
+		switch key {
+		case "JIRA_API_TOKEN":
+			credentials.Token = value
+		case "JIRA_URL":
+			credentials.URL = value
+		case "JIRA_USERNAME":
+			credentials.Username = value
+		default:
+			evidence.PrintWarning("Unknown key '%s' in credentials file %s", key, path)
+		}
+	}
+
+	return credentials, nil
+}
+
+// unquote strips one pair of matching single or double quotes around a value
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
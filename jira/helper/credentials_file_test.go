@@ -0,0 +1,166 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadCredentialsFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	tests := []struct {
+		name            string
+		content         string
+		perm            os.FileMode
+		expected        *Credentials
+		expectError     bool
+		errorContains   string
+		expectedWarning string
+	}{
+		{
+			name: "All credentials",
+			content: `# JIRA Configuration
+JIRA_API_TOKEN=secret-token
+export JIRA_URL="https://example.atlassian.net"
+JIRA_USERNAME = 'ci@example.com'
+`,
+			perm: 0600,
+			expected: &Credentials{
+				Token:    "secret-token",
+				URL:      "https://example.atlassian.net",
+				Username: "ci@example.com",
+			},
+		},
+		{
+			name:     "Token only",
+			content:  "JIRA_API_TOKEN=secret-token\n",
+			perm:     0400,
+			expected: &Credentials{Token: "secret-token"},
+		},
+		{
+			name:            "Readable by others warns",
+			content:         "JIRA_API_TOKEN=secret-token\n",
+			perm:            0644,
+			expected:        &Credentials{Token: "secret-token"},
+			expectedWarning: "readable by other users",
+		},
+		{
+			name:            "Unknown keys warn",
+			content:         "JIRA_API_TOKEN=secret-token\nTEST_EXISTING_JIRA_ID=TEST-123\n",
+			perm:            0600,
+			expected:        &Credentials{Token: "secret-token"},
+			expectedWarning: "Unknown key 'TEST_EXISTING_JIRA_ID'",
+		},
+		{
+			name:          "Writable by others is rejected",
+			content:       "JIRA_API_TOKEN=secret-token\n",
+			perm:          0620,
+			expectError:   true,
+			errorContains: "writable by other users",
+		},
+		{
+			name:          "Malformed line",
+			content:       "JIRA_API_TOKEN=secret-token\nnot a pair\n",
+			perm:          0600,
+			expectError:   true,
+			errorContains: "line 2 is not KEY=VALUE",
+		},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(tempDir, string(rune('a'+i))+".env")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0600))
+			require.NoError(t, os.Chmod(path, tt.perm))
+
+			// Capture stderr for warnings
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			credentials, err := LoadCredentialsFile(path)
+
+			w.Close()
+			os.Stderr = oldStderr
+			stderrOutput, _ := io.ReadAll(r)
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorContains)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, credentials)
+			if tt.expectedWarning != "" {
+				assert.Contains(t, string(stderrOutput), tt.expectedWarning)
+			} else {
+				assert.Empty(t, string(stderrOutput))
+			}
+		})
+	}
+
+	t.Run("Missing file", func(t *testing.T) {
+		_, err := LoadCredentialsFile(filepath.Join(tempDir, "missing.env"))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error reading credentials file")
+	})
+}
+
+func TestLoadConfigWithCredentialsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jira-credentials")
+	require.NoError(t, os.WriteFile(path, []byte(`JIRA_API_TOKEN=file-token
+JIRA_URL=https://file.atlassian.net
+JIRA_USERNAME=file-user@example.com
+`), 0600))
+
+	for _, name := range []string{"JIRA_API_TOKEN", "JIRA_URL", "JIRA_USERNAME", "JIRA_CREDENTIALS_FILE"} {
+		t.Setenv(name, "")
+	}
+
+	t.Run("Credentials file fills in unset values", func(t *testing.T) {
+		config, err := LoadConfig(&FlagConfig{CredentialsFile: path}, []string{"EV-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "file-token", config.JIRAToken)
+		assert.Equal(t, "https://file.atlassian.net", config.JIRAURL)
+		assert.Equal(t, "file-user@example.com", config.JIRAUsername)
+	})
+
+	t.Run("JIRA_CREDENTIALS_FILE is used without the flag", func(t *testing.T) {
+		t.Setenv("JIRA_CREDENTIALS_FILE", path)
+
+		config, err := LoadConfig(&FlagConfig{}, []string{"EV-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "file-token", config.JIRAToken)
+	})
+
+	t.Run("Environment variables take precedence", func(t *testing.T) {
+		t.Setenv("JIRA_API_TOKEN", "env-token")
+		t.Setenv("JIRA_URL", "https://env.atlassian.net")
+
+		config, err := LoadConfig(&FlagConfig{CredentialsFile: path}, []string{"EV-1"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "env-token", config.JIRAToken)
+		assert.Equal(t, "https://env.atlassian.net", config.JIRAURL)
+		assert.Equal(t, "file-user@example.com", config.JIRAUsername)
+	})
+
+	t.Run("Not read when no credentials are needed", func(t *testing.T) {
+		config, err := LoadConfig(&FlagConfig{CredentialsFile: filepath.Join(t.TempDir(), "missing"), ExtractOnly: true}, []string{"abc123"})
+
+		require.NoError(t, err)
+		assert.Empty(t, config.JIRAToken)
+	})
+
+	t.Run("Invalid credentials file", func(t *testing.T) {
+		_, err := LoadConfig(&FlagConfig{CredentialsFile: filepath.Join(t.TempDir(), "missing")}, []string{"EV-1"})
+		assert.Error(t, err)
+	})
+}
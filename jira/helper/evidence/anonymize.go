@@ -0,0 +1,111 @@
+package evidence
+
+// Anonymize returns a copy of the response with every person (assignee, reporter and
+// transition authors, together with their emails and IDs) replaced by "User A", "User B", ...
+// in order of first appearance. The same person gets the same label throughout the response,
+// so the evidence still shows who moved which ticket without revealing identities.
+func Anonymize(response TransitionCheckResponse) TransitionCheckResponse {
+	labels := &userLabels{byIdentity: make(map[string]string)}
+
+	anonymized := response
+	anonymized.Tasks = make([]JiraTransitionResult, len(response.Tasks))
+	for i, task := range response.Tasks {
+		if task.Assignee != nil || task.AssigneeID != "" {
+			name := ""
+			if task.Assignee != nil {
+				name = *task.Assignee
+			}
+			assignee := labels.label(name, task.AssigneeID)
+			if task.Assignee != nil {
+				task.Assignee = &assignee
+			}
+			if task.AssigneeID != "" {
+				task.AssigneeID = assignee
+			}
+		}
+		reporter := labels.label(task.Reporter, task.ReporterID)
+		task.Reporter = reporter
+		if task.ReporterID != "" {
+			task.ReporterID = reporter
+		}
+
+		if task.Transitions != nil {
+			transitions := make([]Transition, len(task.Transitions))
+			for j, transition := range task.Transitions {
+				author := labels.label(transition.Author, transition.AuthorEmail)
+				transition.Author = author
+				if transition.AuthorEmail != "" {
+					transition.AuthorEmail = author
+				}
+				transitions[j] = transition
+			}
+			task.Transitions = transitions
+		}
+
+		if task.Commits != nil {
+			commits := make([]CommitRef, len(task.Commits))
+			for j, commit := range task.Commits {
+				author := labels.label(commit.Author, commit.AuthorEmail)
+				commit.Author = author
+				if commit.AuthorEmail != "" {
+					commit.AuthorEmail = author
+				}
+				commits[j] = commit
+			}
+			task.Commits = commits
+		}
+
+		anonymized.Tasks[i] = task
+	}
+
+	return anonymized
+}
+
+// userLabels hands out "User A", "User B", ... and remembers which name or ID got which label
+type userLabels struct {
+	byIdentity map[string]string
+	count      int
+}
+
+// label returns the label of the person with this display name and ID (email or accountId),
+// or "" if both are empty. The ID is looked up first since display names are not unique.
+func (u *userLabels) label(name, id string) string {
+	if name == "" && id == "" {
+		return ""
+	}
+
+	label, found := "", false
+	if id != "" {
+		label, found = u.byIdentity["id:"+id]
+	}
+	if !found && name != "" {
+		label, found = u.byIdentity["name:"+name]
+	}
+	if !found {
+		u.count++
+		label = "User " + labelSuffix(u.count)
+	}
+
+	if id != "" {
+		if _, exists := u.byIdentity["id:"+id]; !exists {
+			u.byIdentity["id:"+id] = label
+		}
+	}
+	if name != "" {
+		if _, exists := u.byIdentity["name:"+name]; !exists {
+			u.byIdentity["name:"+name] = label
+		}
+	}
+	return label
+}
+
+// labelSuffix turns 1, 2, ..., 26, 27 into A, B, ..., Z, AA like spreadsheet columns
+func labelSuffix(n int) string {
+	suffix := ""
+	for n > 0 {
+		n--
+		suffix = string(rune('A'+n%26)) + suffix
+		n /= 26
+	}
+	return suffix
+}
@@ -0,0 +1,96 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnonymize(t *testing.T) {
+	jane, john := "Jane Doe", "John Smith"
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{
+				Key:        "EV-1",
+				Assignee:   &jane,
+				AssigneeID: "jane@example.com",
+				Reporter:   "John Smith",
+				Transitions: []Transition{
+					{FromStatus: "To Do", ToStatus: "In Progress", Author: "Jane Doe", AuthorEmail: "jane@example.com"},
+					{FromStatus: "In Progress", ToStatus: "Done", Author: "Bob Jones", AuthorEmail: "bob@example.com"},
+				},
+			},
+			{
+				Key:      "EV-2",
+				Assignee: &john,
+				Reporter: "Jane Doe",
+				Commits: []CommitRef{
+					{Hash: "abc123", Author: "jdoe", AuthorEmail: "jane@example.com", Date: "2024-01-15T10:00:00+01:00"},
+					{Hash: "def456", Author: "Bob Jones", AuthorEmail: "bob@example.com"},
+				},
+				Transitions: []Transition{
+					{FromStatus: "To Do", ToStatus: "Done", Author: "J. Doe", AuthorEmail: "jane@example.com"},
+				},
+			},
+			{
+				Key:         "EV-3",
+				Status:      ErrorStatus,
+				Transitions: []Transition{},
+			},
+		},
+	}
+
+	anonymized := Anonymize(response)
+
+	userA, userB := "User A", "User B"
+	assert.Equal(t, []JiraTransitionResult{
+		{
+			Key:        "EV-1",
+			Assignee:   &userA,
+			AssigneeID: "User A",
+			Reporter:   "User B",
+			Transitions: []Transition{
+				{FromStatus: "To Do", ToStatus: "In Progress", Author: "User A", AuthorEmail: "User A"},
+				{FromStatus: "In Progress", ToStatus: "Done", Author: "User C", AuthorEmail: "User C"},
+			},
+		},
+		{
+			Key:      "EV-2",
+			Assignee: &userB,
+			Reporter: "User A",
+			Commits: []CommitRef{
+				{Hash: "abc123", Author: "User A", AuthorEmail: "User A", Date: "2024-01-15T10:00:00+01:00"},
+				{Hash: "def456", Author: "User C", AuthorEmail: "User C"},
+			},
+			Transitions: []Transition{
+				{FromStatus: "To Do", ToStatus: "Done", Author: "User A", AuthorEmail: "User A"},
+			},
+		},
+		{
+			Key:         "EV-3",
+			Status:      ErrorStatus,
+			Transitions: []Transition{},
+		},
+	}, anonymized.Tasks)
+
+	// The input is left untouched
+	assert.Equal(t, "Jane Doe", *response.Tasks[0].Assignee)
+	assert.Equal(t, "bob@example.com", response.Tasks[0].Transitions[1].AuthorEmail)
+}
+
+func TestLabelSuffix(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{1, "A"},
+		{26, "Z"},
+		{27, "AA"},
+		{52, "AZ"},
+		{703, "AAA"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, labelSuffix(tt.n))
+	}
+}
@@ -0,0 +1,86 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// WriteFileAtomic creates filename's directory if needed, lets write fill a temp file in that
+// directory and renames it into place once it is complete and synced. The rename stays on one
+// filesystem, which is what makes it atomic. On failure the temp file is removed and any existing
+// file is left untouched. A replaced file keeps its permissions; a new one gets 0644 less the umask.
+func WriteFileAtomic(filename string, write func(io.Writer) error) error {
+	// Create directory if it doesn't exist
+	dir := filepath.Dir(filename)
+	if dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %v", err)
+		}
+	}
+
+	// Unlike os.CreateTemp, which makes the file private, the temp file gets the output's mode
+	perm, existing := os.FileMode(0644), false
+	if info, err := os.Stat(filename); err == nil {
+		perm, existing = info.Mode().Perm(), true
+	}
+	tmp, err := createTemp(dir, "."+filepath.Base(filename)+".tmp-", perm)
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+	committed := false
+	defer func() {
+		if !committed {
+			tmp.Close()
+			os.Remove(tmpName)
+		}
+	}()
+
+	if err := write(tmp); err != nil {
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	// The umask applied to the new temp file must not change the mode of a replaced file
+	if existing {
+		if err := os.Chmod(tmpName, perm); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmpName, filename); err != nil {
+		return err
+	}
+	committed = true
+	return nil
+}
+
+// createTemp creates a new file named prefix followed by a random number in dir, like
+// os.CreateTemp but with perm (before the umask) instead of 0600
+func createTemp(dir, prefix string, perm os.FileMode) (*os.File, error) {
+	for try := 0; ; try++ {
+		name := filepath.Join(dir, prefix+strconv.FormatUint(uint64(rand.Uint32()), 10))
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if errors.Is(err, os.ErrExist) && try < 10000 {
+			continue
+		}
+		return f, err
+	}
+}
+
+// writeFile writes data to filename through WriteFileAtomic, so a killed run never leaves a
+// truncated report behind
+func writeFile(filename string, data []byte) error {
+	return WriteFileAtomic(filename, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
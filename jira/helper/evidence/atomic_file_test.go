@@ -0,0 +1,123 @@
+package evidence
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFileAtomic_Interrupted(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "transition-check.json")
+	assert.NoError(t, os.WriteFile(filename, []byte(`{"tasks":[]}`), 0644))
+
+	// The write fails after half of the new content is out
+	err := WriteFileAtomic(filename, func(w io.Writer) error {
+		if _, err := w.Write([]byte(`{"tasks":[{"key":`)); err != nil {
+			return err
+		}
+		return errors.New("killed")
+	})
+	assert.EqualError(t, err, "killed")
+
+	// The previous file is intact and the partial temp file is gone
+	content, err := os.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"tasks":[]}`, string(content))
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+
+	// A failed rename (the target is a directory) also leaves nothing behind
+	target := filepath.Join(dir, "out")
+	assert.NoError(t, os.MkdirAll(filepath.Join(target, "keep"), 0755))
+	assert.Error(t, writeFile(target, []byte("data")))
+	entries, err = os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestWriteFileAtomic_Permissions(t *testing.T) {
+	dir := t.TempDir()
+
+	// A rewritten file keeps its mode, even a private one
+	private := filepath.Join(dir, "private.json")
+	assert.NoError(t, os.WriteFile(private, []byte("old"), 0600))
+	assert.NoError(t, os.Chmod(private, 0600))
+	assert.NoError(t, writeFile(private, []byte("new")))
+	info, err := os.Stat(private)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// A new file gets the same mode as one created with 0644 under the current umask
+	reference := filepath.Join(dir, "reference")
+	assert.NoError(t, os.WriteFile(reference, nil, 0644))
+	referenceInfo, err := os.Stat(reference)
+	assert.NoError(t, err)
+	created := filepath.Join(dir, "new.json")
+	assert.NoError(t, writeFile(created, []byte("new")))
+	info, err = os.Stat(created)
+	assert.NoError(t, err)
+	assert.Equal(t, referenceInfo.Mode().Perm(), info.Mode().Perm())
+}
+
+func TestGenerateMarkdownFromJSON_Atomic(t *testing.T) {
+	dir := t.TempDir()
+	inputFile := filepath.Join(dir, "evidence.json")
+	outputFile := filepath.Join(dir, "report.md")
+	assert.NoError(t, os.WriteFile(inputFile, []byte(`{"tasks":[{"key":"EV-1","status":"Done"}]}`), 0644))
+	assert.NoError(t, os.WriteFile(outputFile, []byte("previous report"), 0644))
+
+	// A reader that opened the previous report keeps seeing all of it: the new report is
+	// renamed into place instead of truncating and rewriting the file
+	reader, err := os.Open(outputFile)
+	assert.NoError(t, err)
+	defer reader.Close()
+
+	assert.NoError(t, GenerateMarkdownFromJSON(inputFile, outputFile, MarkdownOptions{}))
+
+	previous, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "previous report", string(previous))
+	report, err := os.ReadFile(outputFile)
+	assert.NoError(t, err)
+	assert.Contains(t, string(report), "EV-1")
+	assertNoTempFiles(t, dir)
+
+	// A report that can't be put in place (the target is a directory) leaves nothing behind
+	assert.Error(t, GenerateMarkdownFromJSON(inputFile, dir, MarkdownOptions{}))
+	assertNoTempFiles(t, filepath.Dir(dir))
+}
+
+func TestGenerateSplitMarkdown_Interrupted(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "wiki")
+	response := TransitionCheckResponse{Tasks: []JiraTransitionResult{{Key: "EV-1", Status: "Done"}, {Key: "EV-2", Status: "Done"}}}
+	assert.NoError(t, GenerateSplitMarkdown(response, outputDir, MarkdownOptions{}))
+	index, err := os.ReadFile(filepath.Join(outputDir, SplitIndexFile))
+	assert.NoError(t, err)
+
+	// The second ticket's file can't be written, so the run stops before the new index
+	response.Tasks = append(response.Tasks, JiraTransitionResult{Key: "EV-3", Status: "Done"})
+	assert.NoError(t, os.Mkdir(filepath.Join(outputDir, "EV-3.md"), 0755))
+	assert.Error(t, GenerateSplitMarkdown(response, outputDir, MarkdownOptions{}))
+
+	unchanged, err := os.ReadFile(filepath.Join(outputDir, SplitIndexFile))
+	assert.NoError(t, err)
+	assert.Equal(t, string(index), string(unchanged), "the previous index is intact")
+	assertNoTempFiles(t, outputDir)
+}
+
+// assertNoTempFiles checks that no WriteFileAtomic temp file was left in dir
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	for _, entry := range entries {
+		assert.False(t, strings.Contains(entry.Name(), ".tmp-"), "temp file %s left behind", entry.Name())
+	}
+}
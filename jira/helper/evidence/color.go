@@ -0,0 +1,71 @@
+package evidence
+
+import (
+	"fmt"
+	"os"
+	"sync"
+)
+
+// ANSI escape codes used to color terminal output
+const (
+	colorRed    = "\033[31m"
+	colorYellow = "\033[33m"
+	colorGreen  = "\033[32m"
+	colorReset  = "\033[0m"
+)
+
+// colorDisabled turns off colored output even when writing to a terminal
+var colorDisabled bool
+
+// ConfigureColor applies the --no-color flag and the NO_COLOR convention (https://no-color.org)
+func ConfigureColor(noColor bool) {
+	colorDisabled = noColor || os.Getenv("NO_COLOR") != ""
+}
+
+// printMu serializes the messages below, so lines printed by concurrent fetches never interleave
+var printMu sync.Mutex
+
+// isTerminal reports whether the file is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// colorize wraps text in the given color when f is a terminal and color is enabled
+func colorize(f *os.File, color, text string) string {
+	if colorDisabled || !isTerminal(f) {
+		return text
+	}
+	return color + text + colorReset
+}
+
+// PrintError prints an error message to stderr (red on terminals)
+func PrintError(format string, args ...interface{}) {
+	printMu.Lock()
+	defer printMu.Unlock()
+	fmt.Fprintln(os.Stderr, colorize(os.Stderr, colorRed, "❌ "+fmt.Sprintf(format, args...)))
+}
+
+// PrintWarning prints a warning message to stderr (yellow on terminals)
+func PrintWarning(format string, args ...interface{}) {
+	printMu.Lock()
+	defer printMu.Unlock()
+	fmt.Fprintln(os.Stderr, colorize(os.Stderr, colorYellow, "⚠️  "+fmt.Sprintf(format, args...)))
+}
+
+// PrintSuccess prints a success message to stdout (green on terminals)
+func PrintSuccess(format string, args ...interface{}) {
+	printMu.Lock()
+	defer printMu.Unlock()
+	fmt.Fprintln(os.Stdout, colorize(os.Stdout, colorGreen, fmt.Sprintf(format, args...)))
+}
+
+// PrintVerbose prints a --verbose trace line to stderr
+func PrintVerbose(format string, args ...interface{}) {
+	printMu.Lock()
+	defer printMu.Unlock()
+	fmt.Fprintln(os.Stderr, "[verbose] "+fmt.Sprintf(format, args...))
+}
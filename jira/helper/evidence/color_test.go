@@ -0,0 +1,77 @@
+package evidence
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigureColor(t *testing.T) {
+	originalNoColor, hadNoColor := os.LookupEnv("NO_COLOR")
+	defer func() {
+		if hadNoColor {
+			os.Setenv("NO_COLOR", originalNoColor)
+		} else {
+			os.Unsetenv("NO_COLOR")
+		}
+		colorDisabled = false
+	}()
+
+	tests := []struct {
+		name     string
+		noColor  bool
+		envValue string
+		expected bool
+	}{
+		{name: "Color enabled by default", noColor: false, envValue: "", expected: false},
+		{name: "Disabled by --no-color", noColor: true, envValue: "", expected: true},
+		{name: "Disabled by NO_COLOR", noColor: false, envValue: "1", expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("NO_COLOR", tt.envValue)
+			ConfigureColor(tt.noColor)
+			assert.Equal(t, tt.expected, colorDisabled)
+		})
+	}
+}
+
+func TestColorizeNonTerminal(t *testing.T) {
+	// Pipes are not terminals, so output must stay plain
+	r, w, err := os.Pipe()
+	assert.NoError(t, err)
+	defer r.Close()
+	defer w.Close()
+
+	assert.False(t, isTerminal(w))
+	assert.Equal(t, "plain text", colorize(w, colorRed, "plain text"))
+}
+
+func TestPrintHelpers(t *testing.T) {
+	oldStderr := os.Stderr
+	oldStdout := os.Stdout
+	errR, errW, _ := os.Pipe()
+	outR, outW, _ := os.Pipe()
+	os.Stderr = errW
+	os.Stdout = outW
+
+	PrintError("failed: %s", "boom")
+	PrintWarning("careful: %d", 42)
+	PrintSuccess("done")
+
+	errW.Close()
+	outW.Close()
+	os.Stderr = oldStderr
+	os.Stdout = oldStdout
+
+	buf := make([]byte, 1024)
+	n, _ := errR.Read(buf)
+	stderrOutput := string(buf[:n])
+	n, _ = outR.Read(buf)
+	stdoutOutput := string(buf[:n])
+
+	assert.Equal(t, "❌ failed: boom\n⚠️  careful: 42\n", stderrOutput)
+	assert.Equal(t, "done\n", stdoutOutput)
+}
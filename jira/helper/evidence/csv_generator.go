@@ -0,0 +1,33 @@
+package evidence
+
+import (
+	"bytes"
+	"encoding/csv"
+)
+
+// transitionsCSVHeader names the columns of RenderTransitionsCSV
+var transitionsCSVHeader = []string{"key", "from", "to", "author", "author_email", "time"}
+
+// RenderTransitionsCSV flattens the transition history of every task into one CSV row per
+// transition, for loading into a spreadsheet or BI tool. Rows follow the task order of the
+// response and each task's transition order; times are kept as JIRA returned them.
+func RenderTransitionsCSV(response TransitionCheckResponse) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(transitionsCSVHeader); err != nil {
+		return nil, err
+	}
+	for _, task := range response.Tasks {
+		for _, transition := range task.Transitions {
+			row := []string{task.Key, transition.FromStatus, transition.ToStatus, transition.Author, transition.AuthorEmail, transition.TransitionTime}
+			if err := w.Write(row); err != nil {
+				return nil, err
+			}
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,54 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderTransitionsCSV(t *testing.T) {
+	tests := []struct {
+		name     string
+		response TransitionCheckResponse
+		expected string
+	}{
+		{
+			name:     "No tasks writes only the header",
+			response: TransitionCheckResponse{},
+			expected: "key,from,to,author,author_email,time\n",
+		},
+		{
+			name: "One row per transition across tasks",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key: "EV-1",
+						Transitions: []Transition{
+							{FromStatus: "To Do", ToStatus: "In Progress", Author: "Jane Doe", AuthorEmail: "jane@example.com", TransitionTime: "2024-03-05T05:06:07.000+0000"},
+							{FromStatus: "In Progress", ToStatus: "Done", Author: "Jane Doe", AuthorEmail: "jane@example.com", TransitionTime: "2024-03-06T05:06:07.000+0000"},
+						},
+					},
+					{Key: "EV-2", Status: ErrorStatus, Type: ErrorType},
+					{
+						Key: "EV-3",
+						Transitions: []Transition{
+							{FromStatus: "Backlog", ToStatus: "To Do", Author: "Doe, John", TransitionTime: "2024-03-07T05:06:07.000+0000"},
+						},
+					},
+				},
+			},
+			expected: "key,from,to,author,author_email,time\n" +
+				"EV-1,To Do,In Progress,Jane Doe,jane@example.com,2024-03-05T05:06:07.000+0000\n" +
+				"EV-1,In Progress,Done,Jane Doe,jane@example.com,2024-03-06T05:06:07.000+0000\n" +
+				"EV-3,Backlog,To Do,\"Doe, John\",,2024-03-07T05:06:07.000+0000\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := RenderTransitionsCSV(tt.response)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, string(data))
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package evidence
+
+import "fmt"
+
+// GitError represents errors from git operations
+type GitError struct {
+	Operation string
+	Err       error
+}
+
+func (e *GitError) Error() string {
+	return fmt.Sprintf("git operation '%s' failed: %v", e.Operation, e.Err)
+}
+
+func (e *GitError) Unwrap() error {
+	return e.Err
+}
+
+// JiraError represents a failed JIRA request for an issue (or a project, for --list-statuses)
+type JiraError struct {
+	Key        string
+	StatusCode int // HTTP status JIRA answered with, 0 when no response was received
+	Err        error
+}
+
+func (e *JiraError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("JIRA request for '%s' failed with status %d: %v", e.Key, e.StatusCode, e.Err)
+	}
+	return fmt.Sprintf("JIRA request for '%s' failed: %v", e.Key, e.Err)
+}
+
+func (e *JiraError) Unwrap() error {
+	return e.Err
+}
+
+// ValidationError represents validation errors
+type ValidationError struct {
+	Field string
+	Value string
+	Err   error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("validation failed for %s='%s': %v", e.Field, e.Value, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -1,7 +1,8 @@
-package main
+package evidence
 
 import (
 	"errors"
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,6 +46,59 @@ func TestGitError(t *testing.T) {
 	}
 }
 
+func TestJiraError(t *testing.T) {
+	tests := []struct {
+		name          string
+		key           string
+		statusCode    int
+		err           error
+		expectedError string
+	}{
+		{
+			name:          "JiraError with status code",
+			key:           "EV-404",
+			statusCode:    404,
+			err:           errors.New("issue does not exist"),
+			expectedError: "JIRA request for 'EV-404' failed with status 404: issue does not exist",
+		},
+		{
+			name:          "JiraError without response",
+			key:           "EV-1",
+			err:           errors.New("connection refused"),
+			expectedError: "JIRA request for 'EV-1' failed: connection refused",
+		},
+		{
+			name:          "JiraError with nil error",
+			key:           "EV-2",
+			statusCode:    403,
+			err:           nil,
+			expectedError: "JIRA request for 'EV-2' failed with status 403: <nil>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jiraErr := &JiraError{
+				Key:        tt.key,
+				StatusCode: tt.statusCode,
+				Err:        tt.err,
+			}
+			assert.Equal(t, tt.expectedError, jiraErr.Error())
+		})
+	}
+
+	t.Run("Unwrap and errors.As", func(t *testing.T) {
+		cause := errors.New("network unreachable")
+		var err error = fmt.Errorf("fetch: %w", &JiraError{Key: "EV-1", StatusCode: 503, Err: cause})
+
+		var jiraErr *JiraError
+		assert.True(t, errors.As(err, &jiraErr))
+		assert.Equal(t, "EV-1", jiraErr.Key)
+		assert.Equal(t, 503, jiraErr.StatusCode)
+		assert.ErrorIs(t, err, cause)
+	})
+}
+
 func TestValidationError(t *testing.T) {
 	tests := []struct {
 		name          string
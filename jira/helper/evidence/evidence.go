@@ -0,0 +1,78 @@
+// Package evidence extracts JIRA ticket IDs from git history, fetches their details and
+// transitions from JIRA, and renders them as JSON evidence or a markdown report.
+//
+// The jira-helper command is a thin wrapper around this package; other Go programs can use it
+// directly instead of running the binary and parsing its output.
+package evidence
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// DefaultJIRAIDRegex is the pattern used to find JIRA IDs when none is configured
+const DefaultJIRAIDRegex = "[A-Z]+-[0-9]+"
+
+// ExtractIDs returns the unique JIRA IDs referenced by the commits in fromRef..toRef of the git
+// repository in the current working directory
+func ExtractIDs(fromRef, toRef, jiraIDRegex string, options ExtractOptions) ([]string, error) {
+	return NewGitServiceWithOptions(options).ExtractJiraIDsBetween(fromRef, toRef, getOrDefault(jiraIDRegex, DefaultJIRAIDRegex))
+}
+
+// ExtractIDsFromText returns the unique JIRA IDs referenced anywhere in text, such as a saved pull
+// request description, sorted by key. The regex and the options (project regexes, ignored keys,
+// case-insensitive matching, first match per line) apply as they do to commit messages.
+func ExtractIDsFromText(text, jiraIDRegex string, options ExtractOptions) ([]string, error) {
+	regex, err := NewGitServiceWithOptions(options).compileJiraIDRegex(getOrDefault(jiraIDRegex, DefaultJIRAIDRegex))
+	if err != nil {
+		return nil, err
+	}
+
+	jiraIDs := extractUniqueJIRAIDs(text, "", regex, options)
+	sort.Slice(jiraIDs, func(i, j int) bool { return compareKeys(jiraIDs[i], jiraIDs[j]) < 0 })
+	return jiraIDs, nil
+}
+
+// FetchDetails fetches the details and status transitions of the given JIRA IDs.
+// Credentials are read from JIRA_API_TOKEN, JIRA_URL and JIRA_USERNAME, falling back to the
+// values set in options. Tickets that can't be fetched are returned as error results.
+func FetchDetails(jiraIDs []string, options JiraClientOptions) (TransitionCheckResponse, error) {
+	client, err := NewJiraClient(options)
+	if err != nil {
+		return TransitionCheckResponse{}, err
+	}
+	return client.FetchJiraDetails(jiraIDs), nil
+}
+
+// RenderMarkdown renders a response as a markdown report
+func RenderMarkdown(response TransitionCheckResponse, options MarkdownOptions) string {
+	return generateMarkdown(response, options)
+}
+
+// LoadResponseFile reads and parses a JSON file containing a TransitionCheckResponse
+func LoadResponseFile(filename string) (TransitionCheckResponse, error) {
+	var response TransitionCheckResponse
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return response, fmt.Errorf("error reading JSON file: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return response, fmt.Errorf("error parsing JSON: %v", err)
+	}
+
+	return response, nil
+}
+
+// getOrDefault returns the first non-empty value
+func getOrDefault(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,121 @@
+package evidence
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractIDs(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Git not installed, skipping real repository test")
+	}
+
+	// Build a small repository with a tag and two ticket commits after it
+	repoDir := t.TempDir()
+	runGit := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "HOME="+repoDir, "GIT_CONFIG_NOSYSTEM=1")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return string(output)
+	}
+	runGit("init", "-q")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test")
+	runGit("commit", "-q", "--allow-empty", "-m", "EV-1: Initial commit")
+	runGit("tag", "v1.0.0")
+	runGit("commit", "-q", "--allow-empty", "-m", "EV-2: Add feature related to OPS-9")
+	runGit("commit", "-q", "--allow-empty", "-m", "EV-3: Fix bug")
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(repoDir))
+	defer os.Chdir(oldDir)
+	t.Setenv("HOME", repoDir)
+
+	ids, err := ExtractIDs("v1.0.0", "HEAD", "", ExtractOptions{})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"EV-2", "OPS-9", "EV-3"}, ids)
+
+	ids, err = ExtractIDs("v1.0.0", "HEAD", "EV-[0-9]+", ExtractOptions{FirstOnly: true})
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"EV-2", "EV-3"}, ids)
+
+	_, err = ExtractIDs("v9.9.9", "HEAD", "", ExtractOptions{})
+	assert.Error(t, err)
+}
+
+func TestExtractIDsFromText(t *testing.T) {
+	text := "Fixes EV-10 and ev-3.\n\nFollow-up of EV-10, related to UTF-8 and OPS#7.\n"
+
+	tests := []struct {
+		name     string
+		regex    string
+		options  ExtractOptions
+		expected []string
+	}{
+		{"Default regex, sorted by key", "", ExtractOptions{}, []string{"EV-10", "UTF-8"}},
+		{"Ignored project keys", "", ExtractOptions{IgnoreKeys: []string{"UTF"}}, []string{"EV-10"}},
+		{"Case-insensitive matches are upper-cased", "EV-[0-9]+", ExtractOptions{IgnoreCase: true}, []string{"EV-3", "EV-10"}},
+		{"First match per line", "", ExtractOptions{FirstOnly: true}, []string{"EV-10"}},
+		{"Project regexes", "EV-[0-9]+", ExtractOptions{ProjectRegexes: map[string]string{"ops": "OPS#[0-9]+"}}, []string{"EV-10", "OPS#7"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := ExtractIDsFromText(text, tt.regex, tt.options)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, ids)
+		})
+	}
+
+	_, err := ExtractIDsFromText(text, "EV-[0-9", ExtractOptions{})
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestFetchDetailsRequiresCredentials(t *testing.T) {
+	t.Setenv("JIRA_API_TOKEN", "")
+
+	_, err := FetchDetails([]string{"EV-1"}, JiraClientOptions{})
+
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "JIRA_API_TOKEN", validationErr.Field)
+}
+
+func TestRenderMarkdown(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{{Key: "EV-1", Status: "Done", Type: "Task", Priority: "High"}},
+	}
+
+	markdown := RenderMarkdown(response, MarkdownOptions{})
+
+	assert.Contains(t, markdown, "# JIRA Tasks Report")
+	assert.Contains(t, markdown, "| EV-1 | Done | Task | High | Unassigned |")
+}
+
+func TestLoadResponseFile(t *testing.T) {
+	tempDir := t.TempDir()
+
+	validFile := filepath.Join(tempDir, "valid.json")
+	require.NoError(t, os.WriteFile(validFile, []byte(`{"tasks":[{"key":"EV-1","status":"Done"}]}`), 0644))
+	response, err := LoadResponseFile(validFile)
+	assert.NoError(t, err)
+	require.Len(t, response.Tasks, 1)
+	assert.Equal(t, "EV-1", response.Tasks[0].Key)
+
+	invalidFile := filepath.Join(tempDir, "invalid.json")
+	require.NoError(t, os.WriteFile(invalidFile, []byte(`not json`), 0644))
+	_, err = LoadResponseFile(invalidFile)
+	assert.ErrorContains(t, err, "error parsing JSON")
+
+	_, err = LoadResponseFile(filepath.Join(tempDir, "missing.json"))
+	assert.ErrorContains(t, err, "error reading JSON file")
+}
@@ -0,0 +1,111 @@
+package evidence
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// TaskFields are the JSON names of the JiraTransitionResult fields, in the order they are written
+var TaskFields = jsonFieldNames(reflect.TypeOf(JiraTransitionResult{}))
+
+// jsonFieldNames returns the JSON names of a struct's fields in declaration order
+func jsonFieldNames(t reflect.Type) []string {
+	names := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// ParseFields parses a comma-separated list of task fields (e.g. "key,status,transitions")
+func ParseFields(value string) ([]string, error) {
+	var fields []string
+	for _, field := range strings.Split(value, ",") {
+		if field = strings.TrimSpace(field); field != "" {
+			fields = append(fields, field)
+		}
+	}
+	if err := validateFields(fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// validateFields checks that every name is a JSON field of the tasks (see TaskFields)
+func validateFields(fields []string) error {
+	known := make(map[string]bool, len(TaskFields))
+	for _, name := range TaskFields {
+		known[name] = true
+	}
+	for _, field := range fields {
+		if !known[field] {
+			return &ValidationError{Field: "fields", Value: field, Err: fmt.Errorf("unknown field, must be one of: %s", strings.Join(TaskFields, ", "))}
+		}
+	}
+	return nil
+}
+
+// SelectFields returns the response with each task reduced to the given JSON fields, ready to be
+// marshaled. Fields keep their usual order, and fields that are omitted when empty stay omitted.
+func SelectFields(response TransitionCheckResponse, fields []string) (interface{}, error) {
+	if err := validateFields(fields); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		selected[field] = true
+	}
+
+	tasks := make([]selectedTask, 0, len(response.Tasks))
+	for _, task := range response.Tasks {
+		encoded, err := json.Marshal(task)
+		if err != nil {
+			return nil, err
+		}
+		var values map[string]json.RawMessage
+		if err := json.Unmarshal(encoded, &values); err != nil {
+			return nil, err
+		}
+		for name := range values {
+			if !selected[name] {
+				delete(values, name)
+			}
+		}
+		tasks = append(tasks, selectedTask(values))
+	}
+
+	return struct {
+		Meta  *ResponseMeta  `json:"meta,omitempty"`
+		Tasks []selectedTask `json:"tasks"`
+	}{Meta: response.Meta, Tasks: tasks}, nil
+}
+
+// selectedTask is a task reduced to some of its JSON fields, written in TaskFields order
+type selectedTask map[string]json.RawMessage
+
+func (t selectedTask) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for _, name := range TaskFields {
+		value, ok := t[name]
+		if !ok {
+			continue
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
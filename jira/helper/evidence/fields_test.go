@@ -0,0 +1,96 @@
+package evidence
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    []string
+		expectError bool
+	}{
+		{name: "Single field", value: "key", expected: []string{"key"}},
+		{name: "Spaces and empty entries are ignored", value: " key, status ,,transitions", expected: []string{"key", "status", "transitions"}},
+		{name: "Omitempty field", value: "key,links", expected: []string{"key", "links"}},
+		{name: "Unknown field", value: "key,summary", expectError: true},
+		{name: "Go field name is not a JSON field", value: "ResolutionDate", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := ParseFields(tt.value)
+			if tt.expectError {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "fields", validationErr.Field)
+				assert.Contains(t, err.Error(), "resolution_date")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, fields)
+		})
+	}
+}
+
+func TestSelectFields(t *testing.T) {
+	assignee := "John Doe"
+	response := TransitionCheckResponse{
+		Meta: &ResponseMeta{ToolVersion: "dev", SchemaVersion: SchemaVersion},
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Assignee: &assignee, Links: []IssueLink{{Type: "Blocks", Direction: LinkOutward, Relation: "blocks", Key: "EV-2"}}},
+			{Key: "EV-2", Status: ErrorStatus, ErrorCode: 404},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		fields   []string
+		expected string
+	}{
+		{
+			name:     "Fields keep their usual order",
+			fields:   []string{"status", "key"},
+			expected: `[{"key":"EV-1","status":"Done"},{"key":"EV-2","status":"Error"}]`,
+		},
+		{
+			name:     "Null values are kept",
+			fields:   []string{"key", "assignee"},
+			expected: `[{"key":"EV-1","assignee":"John Doe"},{"key":"EV-2","assignee":null}]`,
+		},
+		{
+			name:     "Empty omitempty fields stay omitted",
+			fields:   []string{"key", "error_code", "links"},
+			expected: `[{"key":"EV-1","links":[{"type":"Blocks","direction":"outward","relation":"blocks","key":"EV-2"}]},{"key":"EV-2","error_code":404}]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := SelectFields(response, tt.fields)
+			require.NoError(t, err)
+
+			data, err := json.Marshal(selected)
+			require.NoError(t, err)
+
+			var decoded struct {
+				Meta  *ResponseMeta   `json:"meta"`
+				Tasks json.RawMessage `json:"tasks"`
+			}
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			assert.Equal(t, response.Meta, decoded.Meta)
+			assert.Equal(t, tt.expected, string(decoded.Tasks))
+		})
+	}
+
+	t.Run("Unknown field", func(t *testing.T) {
+		_, err := SelectFields(response, []string{"summary"})
+		var validationErr *ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
@@ -0,0 +1,156 @@
+package evidence
+
+import (
+	"strings"
+	"time"
+)
+
+// MapStatuses returns a copy of the response in which the current status of each task is renamed
+// through statusMap (from -> to, from compared case-insensitively), and how many tasks were
+// renamed. It normalizes the status names of different projects (e.g. "In QA" and "QA in Progress"
+// to "QA"). Unmapped statuses and tasks that failed to fetch are left unchanged.
+func MapStatuses(response TransitionCheckResponse, statusMap map[string]string) (TransitionCheckResponse, int) {
+	if len(statusMap) == 0 {
+		return response, 0
+	}
+
+	mapping := make(map[string]string, len(statusMap))
+	for from, to := range statusMap {
+		mapping[strings.ToLower(from)] = to
+	}
+
+	mapped := response
+	mapped.Tasks = make([]JiraTransitionResult, len(response.Tasks))
+	renamed := 0
+	for i, task := range response.Tasks {
+		if to, ok := mapping[strings.ToLower(task.Status)]; ok && task.Status != ErrorStatus && to != task.Status {
+			task.Status = to
+			renamed++
+		}
+		mapped.Tasks[i] = task
+	}
+	return mapped, renamed
+}
+
+// IncludeStatuses returns a copy of the response with only the tasks whose current status is one
+// of statuses (compared case-insensitively), and how many tasks were dropped. Tasks that failed to
+// fetch are always kept so failures stay visible; use ExcludeStatuses to drop them.
+func IncludeStatuses(response TransitionCheckResponse, statuses []string) (TransitionCheckResponse, int) {
+	if len(statuses) == 0 {
+		return response, 0
+	}
+
+	included := nameSet(statuses)
+	filtered := response
+	filtered.Tasks = make([]JiraTransitionResult, 0, len(response.Tasks))
+	for _, task := range response.Tasks {
+		if included[strings.ToLower(task.Status)] || task.Status == ErrorStatus {
+			filtered.Tasks = append(filtered.Tasks, task)
+		}
+	}
+	return filtered, len(response.Tasks) - len(filtered.Tasks)
+}
+
+// ExcludeStatuses returns a copy of the response without the tasks whose current status is one
+// of statuses (compared case-insensitively), and how many tasks were dropped.
+func ExcludeStatuses(response TransitionCheckResponse, statuses []string) (TransitionCheckResponse, int) {
+	if len(statuses) == 0 {
+		return response, 0
+	}
+
+	excluded := nameSet(statuses)
+	filtered := response
+	filtered.Tasks = make([]JiraTransitionResult, 0, len(response.Tasks))
+	for _, task := range response.Tasks {
+		if !excluded[strings.ToLower(task.Status)] {
+			filtered.Tasks = append(filtered.Tasks, task)
+		}
+	}
+	return filtered, len(response.Tasks) - len(filtered.Tasks)
+}
+
+// OnlyErrors returns a copy of the response with only the tasks that failed to fetch, and how many
+// tasks were dropped. Without failures the task list is empty rather than nil, so it is written as [].
+func OnlyErrors(response TransitionCheckResponse) (TransitionCheckResponse, int) {
+	filtered := response
+	filtered.Tasks = make([]JiraTransitionResult, 0)
+	for _, task := range response.Tasks {
+		if task.Status == ErrorStatus {
+			filtered.Tasks = append(filtered.Tasks, task)
+		}
+	}
+	return filtered, len(response.Tasks) - len(filtered.Tasks)
+}
+
+// IncludeTypes returns a copy of the response with only the tasks whose issue type is one of types
+// (compared case-insensitively), and how many tasks were dropped. Like IncludeStatuses, tasks that
+// failed to fetch are always kept so failures stay visible.
+func IncludeTypes(response TransitionCheckResponse, types []string) (TransitionCheckResponse, int) {
+	if len(types) == 0 {
+		return response, 0
+	}
+
+	included := nameSet(types)
+	filtered := response
+	filtered.Tasks = make([]JiraTransitionResult, 0, len(response.Tasks))
+	for _, task := range response.Tasks {
+		if included[strings.ToLower(task.Type)] || task.Status == ErrorStatus {
+			filtered.Tasks = append(filtered.Tasks, task)
+		}
+	}
+	return filtered, len(response.Tasks) - len(filtered.Tasks)
+}
+
+// ExcludeTypes returns a copy of the response without the tasks whose issue type is one of types
+// (compared case-insensitively), and how many tasks were dropped. Tasks that failed to fetch have
+// no type and are never dropped.
+func ExcludeTypes(response TransitionCheckResponse, types []string) (TransitionCheckResponse, int) {
+	if len(types) == 0 {
+		return response, 0
+	}
+
+	excluded := nameSet(types)
+	filtered := response
+	filtered.Tasks = make([]JiraTransitionResult, 0, len(response.Tasks))
+	for _, task := range response.Tasks {
+		if !excluded[strings.ToLower(task.Type)] || task.Status == ErrorStatus {
+			filtered.Tasks = append(filtered.Tasks, task)
+		}
+	}
+	return filtered, len(response.Tasks) - len(filtered.Tasks)
+}
+
+// TransitionsSince returns a copy of the response in which each task keeps only the transitions
+// made at or after since, and how many transitions were dropped. Transitions whose time can't be
+// parsed are kept, so a malformed date never hides data.
+func TransitionsSince(response TransitionCheckResponse, since time.Time) (TransitionCheckResponse, int) {
+	if since.IsZero() {
+		return response, 0
+	}
+
+	dropped := 0
+	filtered := response
+	filtered.Tasks = make([]JiraTransitionResult, len(response.Tasks))
+	for i, task := range response.Tasks {
+		transitions := make([]Transition, 0, len(task.Transitions))
+		for _, transition := range task.Transitions {
+			transitionTime, err := time.Parse(JiraTimeFormat, transition.TransitionTime)
+			if err != nil || !transitionTime.Before(since) {
+				transitions = append(transitions, transition)
+			}
+		}
+		dropped += len(task.Transitions) - len(transitions)
+		task.Transitions = transitions
+		filtered.Tasks[i] = task
+	}
+	return filtered, dropped
+}
+
+// nameSet lower-cases names (statuses, issue types) into a set for case-insensitive lookups
+func nameSet(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[strings.ToLower(name)] = true
+	}
+	return set
+}
@@ -0,0 +1,262 @@
+package evidence
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncludeStatuses(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done"},
+			{Key: "EV-2", Status: "In Review"},
+			{Key: "EV-3", Status: "qa"},
+			{Key: "EV-4", Status: ErrorStatus},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		statuses        []string
+		expectedKeys    []string
+		expectedDropped int
+	}{
+		{"No statuses keeps everything", nil, []string{"EV-1", "EV-2", "EV-3", "EV-4"}, 0},
+		{"Statuses are compared case-insensitively", []string{"in review", "QA"}, []string{"EV-2", "EV-3", "EV-4"}, 1},
+		{"Error tasks are always kept", []string{"Done"}, []string{"EV-1", "EV-4"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, dropped := IncludeStatuses(response, tt.statuses)
+
+			var keys []string
+			for _, task := range filtered.Tasks {
+				keys = append(keys, task.Key)
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+			assert.Equal(t, tt.expectedDropped, dropped)
+		})
+	}
+}
+
+func TestExcludeStatuses(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done"},
+			{Key: "EV-2", Status: "In Progress"},
+			{Key: "EV-3", Status: "closed"},
+			{Key: "EV-4", Status: ErrorStatus},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		statuses         []string
+		expectedKeys     []string
+		expectedExcluded int
+	}{
+		{"No statuses keeps everything", nil, []string{"EV-1", "EV-2", "EV-3", "EV-4"}, 0},
+		{"Statuses are compared case-insensitively", []string{"done", "Closed"}, []string{"EV-2", "EV-4"}, 2},
+		{"Unknown statuses drop nothing", []string{"Won't Do"}, []string{"EV-1", "EV-2", "EV-3", "EV-4"}, 0},
+		{"Error tasks can be excluded explicitly", []string{"error"}, []string{"EV-1", "EV-2", "EV-3"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, excluded := ExcludeStatuses(response, tt.statuses)
+
+			var keys []string
+			for _, task := range filtered.Tasks {
+				keys = append(keys, task.Key)
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+			assert.Equal(t, tt.expectedExcluded, excluded)
+		})
+	}
+
+	assert.Len(t, response.Tasks, 4, "the input is left untouched")
+}
+
+func TestMapStatuses(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "In QA"},
+			{Key: "OPS-1", Status: "QA in Progress"},
+			{Key: "EV-2", Status: "Done"},
+			{Key: "EV-3", Status: ErrorStatus},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		statusMap        map[string]string
+		expectedStatuses []string
+		expectedRenamed  int
+	}{
+		{"No map keeps every status", nil, []string{"In QA", "QA in Progress", "Done", ErrorStatus}, 0},
+		{"Statuses are matched case-insensitively", map[string]string{"in qa": "QA", "QA IN PROGRESS": "QA"}, []string{"QA", "QA", "Done", ErrorStatus}, 2},
+		{"Unmapped statuses pass through", map[string]string{"Closed": "Done"}, []string{"In QA", "QA in Progress", "Done", ErrorStatus}, 0},
+		{"Failed fetches are never renamed", map[string]string{"Error": "Failed"}, []string{"In QA", "QA in Progress", "Done", ErrorStatus}, 0},
+		{"Mapping to the same name renames nothing", map[string]string{"Done": "Done"}, []string{"In QA", "QA in Progress", "Done", ErrorStatus}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mapped, renamed := MapStatuses(response, tt.statusMap)
+
+			var statuses []string
+			for _, task := range mapped.Tasks {
+				statuses = append(statuses, task.Status)
+			}
+			assert.Equal(t, tt.expectedStatuses, statuses)
+			assert.Equal(t, tt.expectedRenamed, renamed)
+		})
+	}
+
+	assert.Equal(t, "In QA", response.Tasks[0].Status, "the input is left untouched")
+}
+
+func TestIncludeTypes(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Type: "Bug", Status: "Done"},
+			{Key: "EV-2", Type: "Story", Status: "Done"},
+			{Key: "EV-3", Type: "incident", Status: "Done"},
+			{Key: "EV-4", Status: ErrorStatus},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		types           []string
+		expectedKeys    []string
+		expectedDropped int
+	}{
+		{"No types keeps everything", nil, []string{"EV-1", "EV-2", "EV-3", "EV-4"}, 0},
+		{"Types are compared case-insensitively", []string{"bug", "Incident"}, []string{"EV-1", "EV-3", "EV-4"}, 1},
+		{"Error tasks are always kept", []string{"Epic"}, []string{"EV-4"}, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, dropped := IncludeTypes(response, tt.types)
+
+			var keys []string
+			for _, task := range filtered.Tasks {
+				keys = append(keys, task.Key)
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+			assert.Equal(t, tt.expectedDropped, dropped)
+		})
+	}
+}
+
+func TestExcludeTypes(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Type: "Epic", Status: "Done"},
+			{Key: "EV-2", Type: "Story", Status: "Done"},
+			{Key: "EV-3", Type: "Sub-task", Status: "Done"},
+			{Key: "EV-4", Status: ErrorStatus},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		types            []string
+		expectedKeys     []string
+		expectedExcluded int
+	}{
+		{"No types keeps everything", nil, []string{"EV-1", "EV-2", "EV-3", "EV-4"}, 0},
+		{"Types are compared case-insensitively", []string{"epic", "SUB-TASK"}, []string{"EV-2", "EV-4"}, 2},
+		{"Unknown types drop nothing", []string{"Incident"}, []string{"EV-1", "EV-2", "EV-3", "EV-4"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered, excluded := ExcludeTypes(response, tt.types)
+
+			var keys []string
+			for _, task := range filtered.Tasks {
+				keys = append(keys, task.Key)
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+			assert.Equal(t, tt.expectedExcluded, excluded)
+		})
+	}
+
+	assert.Len(t, response.Tasks, 4, "the input is left untouched")
+}
+
+func TestOnlyErrors(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done"},
+			{Key: "EV-2", Status: ErrorStatus, ErrorCode: 404},
+			{Key: "EV-3", Status: "error"},
+			{Key: "EV-4", Status: ErrorStatus, ErrorCode: 401},
+		},
+	}
+
+	filtered, dropped := OnlyErrors(response)
+	var keys []string
+	for _, task := range filtered.Tasks {
+		keys = append(keys, task.Key)
+	}
+	assert.Equal(t, []string{"EV-2", "EV-4"}, keys)
+	assert.Equal(t, 2, dropped)
+	assert.Len(t, response.Tasks, 4, "the input is left untouched")
+
+	// Without failures the list is empty, not nil, so it is written as []
+	filtered, dropped = OnlyErrors(TransitionCheckResponse{Tasks: []JiraTransitionResult{{Key: "EV-1", Status: "Done"}}})
+	assert.NotNil(t, filtered.Tasks)
+	assert.Empty(t, filtered.Tasks)
+	assert.Equal(t, 1, dropped)
+}
+
+func TestTransitionsSince(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{
+				Key: "EV-1",
+				Transitions: []Transition{
+					{FromStatus: "To Do", ToStatus: "In Progress", TransitionTime: "2023-12-31T23:59:59.000+0000"},
+					{FromStatus: "In Progress", ToStatus: "Done", TransitionTime: "2024-01-01T00:00:00.000+0000"},
+				},
+			},
+			{
+				Key: "EV-2",
+				Transitions: []Transition{
+					{FromStatus: "To Do", ToStatus: "Done", TransitionTime: "not a date"},
+					{FromStatus: "Done", ToStatus: "Reopened", TransitionTime: "2023-06-01T12:00:00.000+0200"},
+				},
+			},
+			{Key: "EV-3", Status: ErrorStatus, Transitions: []Transition{}},
+		},
+	}
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Drops transitions before the date", func(t *testing.T) {
+		filtered, dropped := TransitionsSince(response, since)
+
+		assert.Equal(t, 2, dropped)
+		assert.Len(t, filtered.Tasks, 3)
+		assert.Equal(t, []Transition{response.Tasks[0].Transitions[1]}, filtered.Tasks[0].Transitions)
+		// Malformed dates are kept
+		assert.Equal(t, []Transition{response.Tasks[1].Transitions[0]}, filtered.Tasks[1].Transitions)
+		assert.Empty(t, filtered.Tasks[2].Transitions)
+
+		// The input is not modified
+		assert.Len(t, response.Tasks[0].Transitions, 2)
+	})
+
+	t.Run("Zero date keeps everything", func(t *testing.T) {
+		filtered, dropped := TransitionsSince(response, time.Time{})
+
+		assert.Equal(t, 0, dropped)
+		assert.Equal(t, response, filtered)
+	})
+}
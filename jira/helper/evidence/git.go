@@ -0,0 +1,885 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// gitBinary is the git executable invoked by defaultGitCommand, see SetGitBinary
+var gitBinary = "git"
+
+// SetGitBinary makes all git services created with the default command run the git executable
+// at path (or found on PATH, when path is a bare name) instead of "git". It fails if no such
+// executable exists, so a wrong --git-path is reported at startup rather than on the first command.
+func SetGitBinary(path string) error {
+	resolved, err := exec.LookPath(path)
+	if err != nil {
+		return &ValidationError{Field: "git-path", Value: path, Err: err}
+	}
+	gitBinary = resolved
+	return nil
+}
+
+// GitService handles all git operations
+type GitService struct {
+	execCommand func(args ...string) (string, error)
+	options     ExtractOptions
+}
+
+// ExtractOptions holds optional settings that refine which commits are scanned for JIRA IDs
+type ExtractOptions struct {
+	// Path limits extraction to commits that touched this file or directory
+	Path string
+	// FirstOnly keeps only the first JIRA ID of each commit subject (its "primary" ticket)
+	FirstOnly bool
+	// IgnoreKeys drops IDs whose project key is in the list (e.g. UTF for "UTF-8"), case-insensitively
+	IgnoreKeys []string
+	// UseReflog also scans commits that are only reachable from the branch's reflog, e.g. ones
+	// dropped by a rebase or force-push (experimental, range mode only; see reflogLogArgs)
+	UseReflog bool
+	// NoMerges skips merge commits when scanning a range (a single commit is always scanned)
+	NoMerges bool
+	// ProjectRegexes are additional named patterns matched alongside the primary regex, for
+	// projects whose tickets are referenced differently (see CompileJiraIDRegex)
+	ProjectRegexes map[string]string
+	// IgnoreCase matches the regexes case-insensitively and upper-cases the matched IDs,
+	// so "ev-123" in a commit message is fetched as EV-123
+	IgnoreCase bool
+	// Strict turns the warning about an empty range (start commit resolving to HEAD) into an error
+	Strict bool
+	// MaxMessageLength is how many bytes of each commit message are scanned for JIRA IDs, bounding
+	// the work on pathological commits (default: DefaultMaxMessageLength)
+	MaxMessageLength int
+	// ContinueOnError skips listed commits whose message git fails to read, instead of aborting,
+	// and returns no IDs rather than an error when none of the listed commits can be read
+	ContinueOnError bool
+	// IncludeNotes also scans the git notes attached to the scanned commits (git notes show), for
+	// JIRA references added after the fact; commits without notes are skipped silently
+	IncludeNotes bool
+}
+
+// DefaultMaxMessageLength is the default ExtractOptions.MaxMessageLength
+const DefaultMaxMessageLength = 4096
+
+// NewGitService creates a new git service
+func NewGitService() *GitService {
+	return NewGitServiceWithOptions(ExtractOptions{})
+}
+
+// NewGitServiceWithCommand creates a git service that runs git through execCommand instead of
+// the git binary on PATH, e.g. to run in another directory or to substitute a test double
+func NewGitServiceWithCommand(execCommand func(args ...string) (string, error), options ExtractOptions) *GitService {
+	return &GitService{
+		execCommand: execCommand,
+		options:     options,
+	}
+}
+
+// NewGitServiceWithOptions creates a new git service with extraction options
+func NewGitServiceWithOptions(options ExtractOptions) *GitService {
+	return &GitService{
+		execCommand: defaultGitCommand,
+		options:     options,
+	}
+}
+
+// WithStats makes the service count every git command it runs in stats
+func (g *GitService) WithStats(stats *RunStats) *GitService {
+	execCommand := g.execCommand
+	g.execCommand = func(args ...string) (string, error) {
+		stats.recordGitCommand()
+		return execCommand(args...)
+	}
+	return g
+}
+
+// WithVerbose makes the service log every git command and the number of output lines it
+// returned to stderr. It is a no-op when verbose is false.
+func (g *GitService) WithVerbose(verbose bool) *GitService {
+	if !verbose {
+		return g
+	}
+	execCommand := g.execCommand
+	g.execCommand = func(args ...string) (string, error) {
+		PrintVerbose("git %s", strings.Join(args, " "))
+		output, err := execCommand(args...)
+		if err != nil {
+			PrintVerbose("git failed: %v", err)
+			return output, err
+		}
+		lines := 0
+		if output != "" {
+			lines = len(splitLines(output))
+		}
+		PrintVerbose("git returned %d lines", lines)
+		return output, nil
+	}
+	return g
+}
+
+// defaultGitCommand executes a git command and returns the output
+func defaultGitCommand(args ...string) (string, error) {
+	cmd := exec.Command(gitBinary, args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			err = fmt.Errorf("git executable not found, make sure git is installed and on PATH or pass --git-path: %w", err)
+		}
+		return "", &GitError{Operation: strings.Join(args, " "), Err: err}
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// GetBranchInfo returns current branch name, latest commit hash, and JIRA ID from latest commit
+func (g *GitService) GetBranchInfo() (string, string, string, error) {
+	// Get current branch
+	branchName, err := g.execCommand("branch", "--show-current")
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// Get commit hash and subject in one command to reduce git calls. They are separated by a NUL,
+	// which git doesn't allow in commit messages, so no subject content can shift the fields.
+	commitOutput, err := g.execCommand("log", "-1", "--format=%H%x00%s")
+	if err != nil {
+		return "", "", "", err
+	}
+
+	// A commit with an empty subject (--allow-empty-message) yields the hash and the NUL only
+	commitHash, subject, found := strings.Cut(commitOutput, "\x00")
+	commitHash = strings.TrimSpace(commitHash)
+	if !found || validateCommitHash(commitHash) != nil {
+		return "", "", "", &GitError{Operation: "log -1", Err: fmt.Errorf("unexpected output format")}
+	}
+	subject = strings.TrimSpace(subject)
+
+	// Extract JIRA ID using default pattern
+	jiraID := extractFirstJIRAID(subject, DefaultJIRAIDRegex)
+
+	return branchName, commitHash, jiraID, nil
+}
+
+// ValidateCommit checks if a commit exists in the repository
+func (g *GitService) ValidateCommit(commit string) error {
+	// First validate the commit hash format
+	if err := validateCommitHash(commit); err != nil {
+		return err
+	}
+
+	if _, err := g.execCommand("rev-parse", "--verify", commit); err != nil {
+		return &GitError{Operation: "rev-parse --verify", Err: fmt.Errorf("commit '%s' not found", commit)}
+	}
+	return nil
+}
+
+// GetRemoteURL returns the URL of the origin remote, or "" when the repository has none.
+// Credentials embedded in an http(s) URL (e.g. a CI job token) are removed, since the
+// URL is recorded in the evidence file.
+func (g *GitService) GetRemoteURL() (string, error) {
+	remoteURL, err := g.execCommand("config", "--get", "remote.origin.url")
+	if err != nil {
+		// git config exits with 1 when the key isn't set
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return "", nil
+		}
+		return "", err
+	}
+
+	if parsed, err := url.Parse(remoteURL); err == nil && parsed.User != nil {
+		parsed.User = nil
+		remoteURL = parsed.String()
+	}
+	return remoteURL, nil
+}
+
+// GetHeadCommit returns the full hash of the HEAD commit
+func (g *GitService) GetHeadCommit() (string, error) {
+	return g.execCommand("rev-parse", "HEAD")
+}
+
+// GetCommitTime returns the committer date of commit, the time it was added to the history (unlike
+// the author date, which a rebase or cherry-pick keeps)
+func (g *GitService) GetCommitTime(commit string) (time.Time, error) {
+	output, err := g.execCommand("log", "-1", "--format=%ct", commit)
+	if err != nil {
+		return time.Time{}, &GitError{Operation: "log", Err: fmt.Errorf("cannot read the commit date of '%s': %w", commit, err)}
+	}
+	seconds, err := strconv.ParseInt(output, 10, 64)
+	if err != nil {
+		return time.Time{}, &GitError{Operation: "log", Err: fmt.Errorf("invalid commit date '%s' of '%s'", output, commit)}
+	}
+	return time.Unix(seconds, 0), nil
+}
+
+// IsShallow reports whether the repository is a shallow clone (e.g. git clone --depth 1), whose
+// history stops at a cut-off so older commits are missing
+func (g *GitService) IsShallow() (bool, error) {
+	output, err := g.execCommand("rev-parse", "--is-shallow-repository")
+	if err != nil {
+		return false, &GitError{Operation: "rev-parse --is-shallow-repository", Err: err}
+	}
+	switch output {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	}
+	// git before 2.15 echoes the unknown option back
+	return false, &GitError{Operation: "rev-parse --is-shallow-repository", Err: fmt.Errorf("unsupported by this git version")}
+}
+
+// warnIfShallow warns that a commit range may be incomplete in a shallow clone. Range extraction
+// otherwise silently misses the commits beyond the clone's depth, typically in CI checkouts.
+func (g *GitService) warnIfShallow() {
+	// Not knowing (e.g. an old git) is no reason to fail the extraction; --verbose shows the error
+	if shallow, err := g.IsShallow(); err == nil && shallow {
+		PrintWarning("The repository is a shallow clone: commits beyond its depth are missing, so JIRA IDs in the range may be incomplete. " +
+			"Fetch the full history first (git fetch --unshallow, or fetch-depth: 0 with actions/checkout)")
+	}
+}
+
+// ValidateHEAD checks if HEAD commit exists in the repository
+func (g *GitService) ValidateHEAD() error {
+	if _, err := g.execCommand("rev-parse", "--verify", "HEAD"); err != nil {
+		return &GitError{Operation: "rev-parse --verify HEAD", Err: fmt.Errorf("repository may be empty or corrupted")}
+	}
+	return nil
+}
+
+// ExtractJiraIDs extracts JIRA IDs from git commit messages
+func (g *GitService) ExtractJiraIDs(startCommit, jiraIDRegex, currentJiraID string, singleCommit bool) ([]string, error) {
+	// Validate commit first
+	if err := g.ValidateCommit(startCommit); err != nil {
+		return nil, err
+	}
+
+	if !singleCommit {
+		g.warnIfShallow()
+	}
+
+	var output string
+	var logArgs []string
+	var err error
+
+	if singleCommit {
+		// Get only the specified commit message
+		logArgs = g.logArgs(true, "-1", subjectFormat, startCommit)
+		output, err = g.execCommand(logArgs...)
+		if err != nil {
+			return nil, err
+		}
+	} else if g.options.UseReflog {
+		logArgs, err = g.reflogLogArgs(startCommit)
+		if err != nil {
+			return nil, err
+		}
+		output, err = g.execCommand(logArgs...)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// startCommit..HEAD silently means something else when startCommit is on another branch
+		isAncestor, err := g.IsAncestor(startCommit, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		if !isAncestor {
+			return nil, &ValidationError{Field: "commit", Value: startCommit, Err: fmt.Errorf("is not an ancestor of HEAD (is it on another branch?), use --from/--to to compare branches")}
+		}
+
+		// Get commit messages from startCommit to HEAD (original behavior)
+		logArgs = g.logArgs(false, subjectFormat, startCommit+"..HEAD")
+		output, err = g.execCommand(logArgs...)
+		if err != nil {
+			return nil, err
+		}
+		if output == "" {
+			if err := g.checkEmptyRange(startCommit); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if output, err = g.appendNotes(output, logArgs); err != nil {
+		return nil, err
+	}
+
+	// Parse regex
+	regex, err := g.compileJiraIDRegex(jiraIDRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	// Extract unique JIRA IDs
+	// In single commit mode, don't add currentJiraID from branch
+	jiraIDToAdd := currentJiraID
+	if singleCommit {
+		jiraIDToAdd = ""
+	}
+	uniqueIDs := extractUniqueJIRAIDs(g.capMessages(output), jiraIDToAdd, regex, g.options)
+
+	if len(uniqueIDs) == 0 {
+		if singleCommit {
+			PrintWarning("No JIRA IDs found in commit %s", startCommit)
+		} else {
+			PrintWarning("No JIRA IDs found in commit range %s..HEAD", startCommit)
+		}
+	}
+
+	return uniqueIDs, nil
+}
+
+// checkEmptyRange explains an empty startCommit..HEAD range that is caused by the start commit
+// being HEAD itself, a common mistake that otherwise silently yields no IDs. It warns, or with
+// the Strict option returns a ValidationError.
+func (g *GitService) checkEmptyRange(startCommit string) error {
+	startSHA, err := g.execCommand("rev-parse", startCommit)
+	if err != nil {
+		return err
+	}
+	headSHA, err := g.GetHeadCommit()
+	if err != nil {
+		return err
+	}
+	if startSHA != headSHA {
+		return nil
+	}
+
+	reason := fmt.Errorf("resolves to HEAD, so the range %s..HEAD is empty; pass an older start commit, or drop --range to scan only this commit", startCommit)
+	if g.options.Strict {
+		return &ValidationError{Field: "commit", Value: startCommit, Err: reason}
+	}
+	PrintWarning("Start commit '%s' %v", startCommit, reason)
+	return nil
+}
+
+// ExtractJiraIDsFromCommits extracts the unique JIRA IDs from the messages of exactly the listed
+// commits, like single-commit mode applied to each of them. Entries that are not commit hashes or
+// don't exist in the repository are reported and skipped; it is an error if none is left. With
+// ContinueOnError, commits git fails to read are skipped too and an empty list is not an error.
+func (g *GitService) ExtractJiraIDsFromCommits(commits []string, jiraIDRegex string) ([]string, error) {
+	regex, err := g.compileJiraIDRegex(jiraIDRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	var subjects []string
+	scanned := 0
+	for _, commit := range commits {
+		if err := g.ValidateCommit(commit); err != nil {
+			PrintWarning("Skipping listed commit '%s': %v", commit, err)
+			continue
+		}
+
+		output, err := g.execCommand(g.logArgs(true, "-1", subjectFormat, commit)...)
+		if err != nil {
+			if !g.options.ContinueOnError {
+				return nil, &GitError{Operation: "log", Err: fmt.Errorf("cannot read commit '%s' (use --continue-on-error to skip it): %w", commit, err)}
+			}
+			PrintWarning("Skipping listed commit '%s': %v", commit, err)
+			continue
+		}
+		subjects = append(subjects, output)
+		scanned++
+
+		// An empty subject means the commit was filtered out by --path, along with its notes
+		if g.options.IncludeNotes && output != "" {
+			notes, err := g.notesLines([]string{commit})
+			if err != nil {
+				return nil, err
+			}
+			subjects = append(subjects, notes...)
+		}
+	}
+
+	if skipped := len(commits) - scanned; skipped > 0 {
+		PrintWarning("Skipped %d of %d listed commits", skipped, len(commits))
+	}
+
+	if scanned == 0 {
+		if g.options.ContinueOnError {
+			return nil, nil
+		}
+		return nil, &ValidationError{Field: "commits-file", Value: strings.Join(commits, ","), Err: fmt.Errorf("lists no valid commits")}
+	}
+
+	uniqueIDs := extractUniqueJIRAIDs(g.capMessages(strings.Join(subjects, "\n")), "", regex, g.options)
+	if len(uniqueIDs) == 0 {
+		PrintWarning("No JIRA IDs found in the %d listed commits", scanned)
+	}
+
+	return uniqueIDs, nil
+}
+
+// reflogLogArgs builds a git log command for the commits reachable from HEAD or from any former
+// tip of the current branch recorded in its reflog, but not from startCommit. This brings back
+// commits a rebase or force-push removed from the branch.
+//
+// It is best-effort: reflogs are local (a fresh CI clone has none), entries expire after
+// gc.reflogExpire (90 days by default) and unreachable commits can be pruned by git gc.
+// On a detached HEAD the reflog of HEAD is used, which also holds every commit checked out.
+func (g *GitService) reflogLogArgs(startCommit string) ([]string, error) {
+	ref := "HEAD"
+	if branch, err := g.execCommand("symbolic-ref", "--quiet", "--short", "HEAD"); err == nil && branch != "" {
+		ref = branch
+	}
+	PrintWarning("--use-reflog is experimental: also scanning former tips of %s from its reflog", ref)
+
+	output, err := g.execCommand("log", "-g", "--pretty=format:%H", ref)
+	if err != nil {
+		return nil, &GitError{Operation: "log -g", Err: fmt.Errorf("cannot read the reflog of %s: %w", ref, err)}
+	}
+
+	revisions := []string{"HEAD"}
+	seen := make(map[string]bool)
+	for _, hash := range splitLines(output) {
+		if hash != "" && !seen[hash] {
+			seen[hash] = true
+			revisions = append(revisions, hash)
+		}
+	}
+	revisions = append(revisions, "^"+startCommit)
+
+	return g.logArgs(false, append([]string{subjectFormat}, revisions...)...), nil
+}
+
+// IsAncestor reports whether commit a is an ancestor of (or the same as) commit b, using
+// git merge-base --is-ancestor, which exits with 1 when it isn't
+func (g *GitService) IsAncestor(a, b string) (bool, error) {
+	_, err := g.execCommand("merge-base", "--is-ancestor", a, b)
+	if err == nil {
+		return true, nil
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, &GitError{Operation: "merge-base --is-ancestor", Err: fmt.Errorf("cannot compare '%s' and '%s': %w", a, b, err)}
+}
+
+// GetMergeBase returns the best common ancestor of refs a and b, e.g. the commit a branch was
+// forked from when a is the base branch and b is HEAD
+func (g *GitService) GetMergeBase(a, b string) (string, error) {
+	if err := g.ValidateRef(a); err != nil {
+		return "", err
+	}
+	if err := g.ValidateRef(b); err != nil {
+		return "", err
+	}
+
+	mergeBase, err := g.execCommand("merge-base", a, b)
+	if err != nil || mergeBase == "" {
+		return "", &GitError{Operation: "merge-base", Err: fmt.Errorf("'%s' and '%s' have no common ancestor", a, b)}
+	}
+	return mergeBase, nil
+}
+
+// ExtractJiraIDsBetween extracts JIRA IDs from commit messages in the range fromRef..toRef
+// Unlike ExtractJiraIDs, both ends may be arbitrary refs such as tags or branches
+func (g *GitService) ExtractJiraIDsBetween(fromRef, toRef, jiraIDRegex string) ([]string, error) {
+	// Validate both refs first
+	if err := g.ValidateRef(fromRef); err != nil {
+		return nil, err
+	}
+	if err := g.ValidateRef(toRef); err != nil {
+		return nil, err
+	}
+	g.warnIfShallow()
+
+	logArgs := g.logArgs(false, subjectFormat, fromRef+".."+toRef)
+	output, err := g.execCommand(logArgs...)
+	if err != nil {
+		return nil, err
+	}
+	if output, err = g.appendNotes(output, logArgs); err != nil {
+		return nil, err
+	}
+
+	// Parse regex
+	regex, err := g.compileJiraIDRegex(jiraIDRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	uniqueIDs := extractUniqueJIRAIDs(g.capMessages(output), "", regex, g.options)
+
+	if len(uniqueIDs) == 0 {
+		PrintWarning("No JIRA IDs found in commit range %s..%s", fromRef, toRef)
+	}
+
+	return uniqueIDs, nil
+}
+
+// commitRefFormat is the git log format read by ExtractCommitRefs: the CommitRef fields and the
+// subject, separated by the ASCII unit separator so names and subjects can contain anything else
+const commitRefFormat = "--pretty=format:%H%x1f%an%x1f%ae%x1f%aI%x1f%s"
+
+// ExtractCommitRefs returns the commits of revisionRange (e.g. "abc123..HEAD") that reference
+// each JIRA ID, newest first. IDs are matched like the ID extraction of the range, with the same
+// options, so the keys are the IDs it returns; an ID added from the branch name has no commits.
+func (g *GitService) ExtractCommitRefs(revisionRange, jiraIDRegex string) (map[string][]CommitRef, error) {
+	regex, err := g.compileJiraIDRegex(jiraIDRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := g.execCommand(g.logArgs(false, commitRefFormat, revisionRange)...)
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make(map[string][]CommitRef)
+	for _, line := range splitLines(output) {
+		fields := strings.SplitN(line, "\x1f", 5)
+		if len(fields) != 5 {
+			continue
+		}
+		commit := CommitRef{Hash: fields[0], Author: fields[1], AuthorEmail: fields[2], Date: fields[3]}
+		// Capped like the ID extraction, which already warned about it
+		subject, _ := truncateMessage(fields[4], g.maxMessageLength())
+		if g.options.IncludeNotes {
+			notes, err := g.notesLines([]string{commit.Hash})
+			if err != nil {
+				return nil, err
+			}
+			for _, note := range notes {
+				note, _ = truncateMessage(note, g.maxMessageLength())
+				subject += "\n" + note
+			}
+		}
+		for _, jiraID := range extractUniqueJIRAIDs(subject, "", regex, g.options) {
+			refs[jiraID] = append(refs[jiraID], commit)
+		}
+	}
+	return refs, nil
+}
+
+// subjectFormat is the git log format of the commit subjects scanned for JIRA IDs
+const subjectFormat = "--pretty=format:%s"
+
+// GetCommitNotes returns the git notes attached to commit (from the default notes ref,
+// refs/notes/commits), or "" when it has none
+func (g *GitService) GetCommitNotes(commit string) (string, error) {
+	output, err := g.execCommand("notes", "show", commit)
+	if err == nil {
+		return output, nil
+	}
+
+	// git notes show exits with 1 when the commit has no notes
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return "", nil
+	}
+	return "", &GitError{Operation: "notes show", Err: fmt.Errorf("cannot read the notes of '%s': %w", commit, err)}
+}
+
+// notesLines returns the notes of the commits, each joined into a single line so it is scanned
+// like a commit subject (and FirstOnly keeps the first ID of each note)
+func (g *GitService) notesLines(commits []string) ([]string, error) {
+	var lines []string
+	for _, commit := range commits {
+		notes, err := g.GetCommitNotes(commit)
+		if err != nil {
+			return nil, err
+		}
+		if line := strings.Join(strings.Fields(notes), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// appendNotes adds the notes of the commits listed by the git log command logArgs to its subject
+// output when the IncludeNotes option is set. The commits are listed by running the same command
+// with their hashes instead of their subjects, so the log options (--path, --no-merges, reflog
+// revisions) select the same commits.
+func (g *GitService) appendNotes(output string, logArgs []string) (string, error) {
+	if !g.options.IncludeNotes {
+		return output, nil
+	}
+
+	hashArgs := make([]string, len(logArgs))
+	for i, arg := range logArgs {
+		if arg == subjectFormat {
+			arg = "--pretty=format:%H"
+		}
+		hashArgs[i] = arg
+	}
+	hashes, err := g.execCommand(hashArgs...)
+	if err != nil {
+		return "", err
+	}
+	if hashes == "" {
+		return output, nil
+	}
+
+	notes, err := g.notesLines(splitLines(hashes))
+	if err != nil {
+		return "", err
+	}
+	if len(notes) == 0 {
+		return output, nil
+	}
+	if output == "" {
+		return strings.Join(notes, "\n"), nil
+	}
+	return output + "\n" + strings.Join(notes, "\n"), nil
+}
+
+// logArgs builds the arguments of a git log command, applying the extraction options
+func (g *GitService) logArgs(singleCommit bool, args ...string) []string {
+	logArgs := []string{"log"}
+	if g.options.NoMerges && !singleCommit {
+		logArgs = append(logArgs, "--no-merges")
+	}
+	logArgs = append(logArgs, args...)
+
+	if g.options.Path != "" {
+		// Without --no-walk, "log -1 <commit> -- <path>" would walk back to an older commit touching the path
+		if singleCommit {
+			logArgs = append(logArgs, "--no-walk")
+		}
+		logArgs = append(logArgs, "--", g.options.Path)
+	}
+
+	return logArgs
+}
+
+// ValidateRef checks if a ref (commit, tag or branch) resolves to a commit in the repository
+func (g *GitService) ValidateRef(ref string) error {
+	if ref == "" {
+		return &ValidationError{Field: "ref", Value: ref, Err: fmt.Errorf("cannot be empty")}
+	}
+
+	// Refs starting with a dash would be interpreted as git options
+	if strings.HasPrefix(ref, "-") {
+		return &ValidationError{Field: "ref", Value: ref, Err: fmt.Errorf("invalid format")}
+	}
+
+	if _, err := g.execCommand("rev-parse", "--verify", ref+"^{commit}"); err != nil {
+		return &GitError{Operation: "rev-parse --verify", Err: fmt.Errorf("ref '%s' not found", ref)}
+	}
+	return nil
+}
+
+// CheckRepository checks if we're in a git repository
+func (g *GitService) CheckRepository() error {
+	if _, err := g.execCommand("rev-parse", "--git-dir"); err != nil {
+		// A missing git binary is not the same as running outside a repository
+		if errors.Is(err, exec.ErrNotFound) {
+			return err
+		}
+		return &GitError{Operation: "rev-parse --git-dir", Err: fmt.Errorf("not in a git repository")}
+	}
+	return nil
+}
+
+// validateCommitHash validates that a commit hash looks valid
+func validateCommitHash(hash string) error {
+	if hash == "" {
+		return &ValidationError{Field: "commit", Value: hash, Err: fmt.Errorf("cannot be empty")}
+	}
+
+	// Basic validation - should be hex characters (allowing short hashes)
+	validHex := regexp.MustCompile("^[a-fA-F0-9]+$")
+	if !validHex.MatchString(hash) {
+		return &ValidationError{Field: "commit", Value: hash, Err: fmt.Errorf("invalid format")}
+	}
+
+	return nil
+}
+
+// compileJiraIDRegex compiles the JIRA ID regex with the service's project regexes and case option
+func (g *GitService) compileJiraIDRegex(jiraIDRegex string) (*regexp.Regexp, error) {
+	regex, err := CompileJiraIDRegex(jiraIDRegex, g.options.ProjectRegexes)
+	if err != nil || !g.options.IgnoreCase {
+		return regex, err
+	}
+	return regexp.Compile("(?i)" + regex.String())
+}
+
+// KeyPatternPresets are the named JIRA ID regexes of --key-pattern. JIRA's own key grammar allows
+// digits after the first letter of a project key (e.g. AB2C-12), which the default regex misses;
+// instances with a customized key format may also allow underscores.
+var KeyPatternPresets = map[string]string{
+	"default":         "[A-Z]+-[0-9]+",
+	"jira-standard":   "[A-Z][A-Z0-9]+-[0-9]+",
+	"jira-underscore": "[A-Z][A-Z0-9_]+-[0-9]+",
+}
+
+// ResolveKeyPattern returns the regex of a --key-pattern preset
+func ResolveKeyPattern(name string) (string, error) {
+	if regex, ok := KeyPatternPresets[strings.ToLower(strings.TrimSpace(name))]; ok {
+		return regex, nil
+	}
+	names := make([]string, 0, len(KeyPatternPresets))
+	for preset := range KeyPatternPresets {
+		names = append(names, preset)
+	}
+	sort.Strings(names)
+	return "", &ValidationError{Field: "key-pattern", Value: name, Err: fmt.Errorf("must be one of: %s", strings.Join(names, ", "))}
+}
+
+// CompileJiraIDRegex compiles the primary JIRA ID regex together with the named project regexes
+// into a single pattern matching any of them. Matches are still found left to right, so with
+// FirstOnly the first ticket of a commit is kept whichever pattern it matched.
+func CompileJiraIDRegex(primary string, projectRegexes map[string]string) (*regexp.Regexp, error) {
+	regex, err := regexp.Compile(primary)
+	if err != nil {
+		return nil, &ValidationError{Field: "jira_id_regex", Value: primary, Err: err}
+	}
+	if len(projectRegexes) == 0 {
+		return regex, nil
+	}
+
+	// Sort the names so the combined pattern (and any error) is deterministic
+	names := make([]string, 0, len(projectRegexes))
+	for name := range projectRegexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	alternatives := []string{"(?:" + primary + ")"}
+	for _, name := range names {
+		pattern := projectRegexes[name]
+		if pattern == "" {
+			return nil, &ValidationError{Field: "project_regexes." + name, Value: pattern, Err: fmt.Errorf("cannot be empty")}
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, &ValidationError{Field: "project_regexes." + name, Value: pattern, Err: err}
+		}
+		alternatives = append(alternatives, "(?:"+pattern+")")
+	}
+
+	return regexp.Compile(strings.Join(alternatives, "|"))
+}
+
+// extractFirstJIRAID extracts the first JIRA ID from a string
+func extractFirstJIRAID(text, pattern string) string {
+	regex, err := regexp.Compile(pattern)
+	if err != nil {
+		return ""
+	}
+
+	matches := regex.FindAllString(text, -1)
+	if len(matches) > 0 {
+		return matches[0]
+	}
+
+	return ""
+}
+
+// maxMessageLength returns the MaxMessageLength option or its default
+func (g *GitService) maxMessageLength() int {
+	if g.options.MaxMessageLength > 0 {
+		return g.options.MaxMessageLength
+	}
+	return DefaultMaxMessageLength
+}
+
+// capMessages truncates each commit message (one per line) of a git log output to the maximum
+// scanned length, and warns how many were truncated
+func (g *GitService) capMessages(output string) string {
+	maxLength := g.maxMessageLength()
+	if len(output) <= maxLength {
+		return output
+	}
+
+	lines := splitLines(output)
+	truncated := 0
+	for i, line := range lines {
+		var wasTruncated bool
+		if lines[i], wasTruncated = truncateMessage(line, maxLength); wasTruncated {
+			truncated++
+		}
+	}
+	if truncated == 0 {
+		return output
+	}
+
+	PrintWarning("Scanned only the first %d bytes of %d oversized commit messages for JIRA IDs (see --max-message-length)", maxLength, truncated)
+	return strings.Join(lines, "\n")
+}
+
+// truncateMessage cuts message to at most maxLength bytes without splitting a UTF-8 character,
+// and reports whether it was cut
+func truncateMessage(message string, maxLength int) (string, bool) {
+	if len(message) <= maxLength {
+		return message, false
+	}
+	cut := maxLength
+	for cut > 0 && !utf8.RuneStart(message[cut]) {
+		cut--
+	}
+	return message[:cut], true
+}
+
+// splitLines splits git output into lines, normalizing CRLF (and lone CR) line endings
+// so a trailing \r from Windows-authored commits never ends up in a match
+func splitLines(output string) []string {
+	output = strings.ReplaceAll(output, "\r\n", "\n")
+	output = strings.ReplaceAll(output, "\r", "\n")
+	return strings.Split(output, "\n")
+}
+
+// extractUniqueJIRAIDs extracts unique JIRA IDs from commit messages (one commit subject per line).
+// IDs with an ignored project key are dropped; with FirstOnly, only the first remaining match of
+// each line is kept.
+func extractUniqueJIRAIDs(commitMessages, currentJiraID string, regex *regexp.Regexp, options ExtractOptions) []string {
+	ignored := make(map[string]bool, len(options.IgnoreKeys))
+	for _, key := range options.IgnoreKeys {
+		ignored[strings.ToUpper(key)] = true
+	}
+	isIgnored := func(jiraID string) bool {
+		projectKey, _, _ := strings.Cut(jiraID, "-")
+		return ignored[strings.ToUpper(projectKey)]
+	}
+
+	// JIRA keys are case-insensitive on lookup, so case-insensitive matches are fetched upper-cased
+	normalize := func(jiraID string) string {
+		if options.IgnoreCase {
+			return strings.ToUpper(jiraID)
+		}
+		return jiraID
+	}
+
+	jiraIDs := make(map[string]bool)
+
+	// Add current JIRA ID if it matches the pattern
+	if currentJiraID != "" && regex.MatchString(currentJiraID) && !isIgnored(currentJiraID) {
+		jiraIDs[normalize(currentJiraID)] = true
+	}
+
+	// Extract from commit messages
+	for _, line := range splitLines(commitMessages) {
+		for _, match := range regex.FindAllString(line, -1) {
+			if isIgnored(match) {
+				continue
+			}
+			jiraIDs[normalize(match)] = true
+			if options.FirstOnly {
+				break
+			}
+		}
+	}
+
+	// Convert map to slice
+	var result []string
+	for jiraID := range jiraIDs {
+		if jiraID != "" {
+			result = append(result, jiraID)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,41 @@
+package evidence
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// CanonicalJSON re-encodes JSON in a canonical form: compact, with the keys of every object sorted
+// and HTML characters left unescaped, followed by a newline. Equal content always gives the same
+// bytes, whatever the field order or indentation of the input; for evidence files it matches the
+// output of `jq -cS .`.
+func CanonicalJSON(data []byte) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+
+	// Objects are decoded into maps, which encoding/json writes with sorted keys
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// ContentHash returns the hex SHA-256 of the canonical form of a JSON document (see CanonicalJSON)
+func ContentHash(data []byte) (string, error) {
+	canonical, err := CanonicalJSON(data)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(canonical)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,69 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "keys sorted at every level",
+			input:    `{"tasks":[{"status":"Done","key":"EV-1"}],"meta":{"tool_version":"v1","generated_at":"2024-05-01T09:30:00Z"}}`,
+			expected: `{"meta":{"generated_at":"2024-05-01T09:30:00Z","tool_version":"v1"},"tasks":[{"key":"EV-1","status":"Done"}]}` + "\n",
+		},
+		{
+			name:     "indentation removed",
+			input:    "{\n  \"key\": \"EV-1\",\n  \"transitions\": [\n    {\"from_status\": \"To Do\"}\n  ]\n}\n",
+			expected: `{"key":"EV-1","transitions":[{"from_status":"To Do"}]}` + "\n",
+		},
+		{
+			name:     "HTML characters and numbers kept as is",
+			input:    `{"description":"a < b && c > d","schema_version":1,"ratio":0.10}`,
+			expected: `{"description":"a < b && c > d","ratio":0.10,"schema_version":1}` + "\n",
+		},
+		{
+			name:        "invalid JSON",
+			input:       `{"key":`,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := CanonicalJSON([]byte(tt.input))
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, string(result))
+		})
+	}
+}
+
+func TestContentHash(t *testing.T) {
+	pretty := "{\n  \"tasks\": [\n    {\n      \"key\": \"EV-1\",\n      \"status\": \"Done\"\n    }\n  ]\n}\n"
+	reordered := `{"tasks":[{"status":"Done","key":"EV-1"}]}`
+
+	hash, err := ContentHash([]byte(pretty))
+	require.NoError(t, err)
+	assert.Len(t, hash, 64)
+
+	// Same content, different layout
+	other, err := ContentHash([]byte(reordered))
+	require.NoError(t, err)
+	assert.Equal(t, hash, other)
+
+	// Different content
+	changed, err := ContentHash([]byte(`{"tasks":[{"key":"EV-1","status":"In Progress"}]}`))
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, changed)
+}
@@ -0,0 +1,120 @@
+package evidence
+
+import (
+	"html/template"
+	"strings"
+)
+
+// htmlReportTemplate lays out the same sections as the markdown report as a standalone page.
+// html/template escapes every value, so ticket text can't inject markup.
+var htmlReportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>JIRA Tasks Report</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+blockquote { white-space: pre-wrap; border-left: 3px solid #ccc; margin-left: 0; padding-left: 1em; }
+</style>
+</head>
+<body>
+<h1>JIRA Tasks Report</h1>
+<p>Generated on: {{.GeneratedOn}}</p>
+<p>Total tasks: {{len .Tasks}}</p>
+<p>Total transitions: {{.TotalTransitions}}</p>
+{{- if .Errors}}
+<p>Tasks with errors: {{len .Errors}}</p>
+{{- end}}
+
+<h2>Summary</h2>
+<table>
+<tr><th>Key</th><th>Status</th><th>Type</th><th>Priority</th><th>Assignee</th><th>Transitions</th></tr>
+{{- range .Tasks}}
+<tr><td>{{template "key" .}}</td><td>{{.Status}}</td><td>{{.Type}}</td><td>{{.Priority}}</td><td>{{.Assignee}}</td><td>{{len .Transitions}}</td></tr>
+{{- end}}
+</table>
+{{- if .Errors}}
+
+<h2>Errors</h2>
+<table>
+<tr><th>Key</th><th>Error</th></tr>
+{{- range .Errors}}
+<tr><td>{{.Key}}</td><td>{{.Description}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+
+<h2>Task Details</h2>
+{{- range $i, $task := .Tasks}}
+<h3>{{inc $i}}. {{template "key" $task}}</h3>
+<ul>
+<li><strong>Status:</strong> {{.Status}}</li>
+<li><strong>Type:</strong> {{.Type}}</li>
+<li><strong>Project:</strong> {{.Project}}</li>
+<li><strong>Priority:</strong> {{.Priority}}</li>
+{{- if .Resolution}}
+<li><strong>Resolution:</strong> {{.Resolution}}</li>
+{{- end}}
+{{- if .ReferencedAs}}
+<li><strong>Referenced As:</strong> {{.ReferencedAs}}</li>
+{{- end}}
+{{- if .RequestedKey}}
+<li><strong>Requested As:</strong> {{.RequestedKey}} (moved)</li>
+{{- end}}
+{{- if .Sprints}}
+<li><strong>Sprints:</strong> {{.Sprints}}</li>
+{{- end}}
+<li><strong>Assignee:</strong> {{.Assignee}}</li>
+<li><strong>Reporter:</strong> {{.Reporter}}</li>
+<li><strong>Created:</strong> {{.Created}}</li>
+<li><strong>Updated:</strong> {{.Updated}}</li>
+{{- if .Resolved}}
+<li><strong>Resolved:</strong> {{.Resolved}}</li>
+{{- end}}
+</ul>
+{{- if .Links}}
+<p><strong>Linked Issues:</strong></p>
+<ul>
+{{- range .Links}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- end}}
+{{- if .Description}}
+<blockquote>{{.Description}}</blockquote>
+{{- end}}
+{{- if .Transitions}}
+<table>
+<tr><th>From Status</th><th>To Status</th><th>Author</th><th>Date</th></tr>
+{{- range .Transitions}}
+<tr><td>{{.FromStatus}}</td><td>{{.ToStatus}}</td><td>{{.Author}}</td><td>{{.Date}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<hr>
+{{- end}}
+
+<h2>Status Distribution</h2>
+<table>
+<tr><th>Status</th><th>Count</th></tr>
+{{- range .StatusCounts}}
+<tr><td>{{.Status}}</td><td>{{.Count}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+{{define "key"}}{{if .Link}}<a href="{{.Link}}">{{.Key}}</a>{{else}}{{.Key}}{{end}}{{end}}`))
+
+// RenderHTML renders a response as a standalone HTML page with the same sections as the
+// markdown report. The markdown options (date format, timezone, sorting, anonymizing) apply.
+func RenderHTML(response TransitionCheckResponse, options MarkdownOptions) (string, error) {
+	var sb strings.Builder
+	if err := htmlReportTemplate.Execute(&sb, newReportView(response, options)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,75 @@
+package evidence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderHTML(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{
+				Key:         "EV-1",
+				Link:        "https://example.atlassian.net/browse/EV-1",
+				Status:      "Done",
+				Type:        "Bug",
+				Priority:    "High",
+				Assignee:    strPtr("Jane Doe"),
+				Reporter:    "John Smith",
+				Created:     "2024-03-04T05:06:07.000+0000",
+				Resolution:  "Fixed",
+				Description: "Fix <script>alert(1)</script> & more",
+				Links:       []IssueLink{{Type: "Blocks", Direction: LinkOutward, Relation: "blocks", Key: "EV-2"}},
+				Transitions: []Transition{
+					{FromStatus: "To Do", ToStatus: "Done", Author: "Jane Doe", TransitionTime: "2024-03-05T05:06:07.000+0000"},
+				},
+			},
+			{
+				Key:         "EV-9",
+				Status:      ErrorStatus,
+				Type:        ErrorType,
+				Description: "Error 404: Could not retrieve issue",
+			},
+		},
+	}
+
+	html, err := RenderHTML(response, MarkdownOptions{DateFormat: "2006-01-02"})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(html, "<!DOCTYPE html>"))
+	assert.Contains(t, html, "<p>Total tasks: 2</p>")
+	assert.Contains(t, html, "<p>Total transitions: 1</p>")
+	assert.Contains(t, html, "<p>Tasks with errors: 1</p>")
+	assert.Contains(t, html, `<a href="https://example.atlassian.net/browse/EV-1">EV-1</a>`)
+	assert.Contains(t, html, "<li><strong>Resolution:</strong> Fixed</li>")
+	assert.Contains(t, html, "<li><strong>Created:</strong> 2024-03-04</li>")
+	assert.Contains(t, html, "<li>blocks EV-2</li>")
+	assert.Contains(t, html, "<tr><td>To Do</td><td>Done</td><td>Jane Doe</td><td>2024-03-05</td></tr>")
+	assert.Contains(t, html, "<tr><td>EV-9</td><td>Error 404: Could not retrieve issue</td></tr>")
+
+	// Ticket text is escaped
+	assert.NotContains(t, html, "<script>")
+	assert.Contains(t, html, "Fix &lt;script&gt;alert(1)&lt;/script&gt; &amp; more")
+
+	// Status distribution is sorted by status
+	assert.Less(t, strings.Index(html, "<tr><td>Done</td><td>1</td></tr>"), strings.Index(html, "<tr><td>Error</td><td>1</td></tr>"))
+}
+
+func TestRenderHTMLOptions(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Priority: "Low", Assignee: strPtr("Jane Doe")},
+			{Key: "EV-2", Status: "Done", Priority: "Highest"},
+		},
+	}
+
+	html, err := RenderHTML(response, MarkdownOptions{SortBy: SortByPriority, Anonymize: true})
+	require.NoError(t, err)
+
+	assert.Less(t, strings.Index(html, "<h3>1. EV-2</h3>"), strings.Index(html, "<h3>2. EV-1</h3>"))
+	assert.NotContains(t, html, "Jane Doe")
+	assert.Contains(t, html, "<li><strong>Assignee:</strong> User A</li>")
+}
@@ -0,0 +1,826 @@
+package evidence
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira/v2/cloud"
+)
+
+// JiraClient wraps the JIRA client and provides methods for JIRA operations
+type JiraClient struct {
+	issues   IssueGetter
+	projects ProjectStatusGetter
+	baseURL  string
+	options  JiraClientOptions
+}
+
+// IssueGetter is the part of the go-jira issue API used by JiraClient. The Issue service of
+// *jira.Client satisfies it; tests inject a fake to exercise the fetch path without network.
+type IssueGetter interface {
+	Get(ctx context.Context, issueID string, options *jira.GetQueryOptions) (*jira.Issue, *jira.Response, error)
+	Search(ctx context.Context, jql string, options *jira.SearchOptions) ([]jira.Issue, *jira.Response, error)
+	SearchPages(ctx context.Context, jql string, options *jira.SearchOptions, f func(jira.Issue) error) error
+}
+
+var _ IssueGetter = (*jira.IssueService)(nil)
+
+// JiraClientOptions holds optional settings that control what the JIRA client fetches
+type JiraClientOptions struct {
+	IncludeSprints bool
+	IncludeUserIDs bool
+	IncludeLinks   bool
+
+	// IncludeSubtasks also fetches the subtasks of every fetched issue, recording their parent
+	IncludeSubtasks bool
+
+	// CollapseRepeatedTransitions keeps only the first of consecutive transitions between the same
+	// two statuses; the later ones' author and time are lost
+	CollapseRepeatedTransitions bool
+
+	// MaxDescription truncates descriptions longer than this many characters, 0 for no limit
+	MaxDescription int
+
+	// KeyAliases maps former project keys to their current key (OLD -> NEW) for renamed projects
+	KeyAliases map[string]string
+
+	// Token, URL and Username are used when JIRA_API_TOKEN/JIRA_URL/JIRA_USERNAME are not set
+	// (e.g. from a --credentials-file or --config file)
+	Token    string
+	URL      string
+	Username string
+
+	// ProxyURL routes JIRA requests through an explicit proxy.
+	// When empty, the standard HTTP_PROXY/HTTPS_PROXY/NO_PROXY variables are honored.
+	ProxyURL string
+
+	// InsecureSkipVerify disables TLS certificate verification (self-signed JIRA instances).
+	// CACertFile is the safer alternative: a PEM file with additional trusted CAs.
+	InsecureSkipVerify bool
+	CACertFile         string
+
+	// MaxIdleConns is how many idle connections to JIRA are kept open for reuse (default: one per
+	// worker, at least http.DefaultMaxIdleConnsPerHost), IdleConnTimeout how long they are kept
+	// (default: 90s), and
+	// DisableKeepAlives opens a new connection for every request
+	MaxIdleConns      int
+	IdleConnTimeout   time.Duration
+	DisableKeepAlives bool
+
+	// Workers is how many tickets are fetched at the same time (default: DefaultWorkers)
+	Workers int
+
+	// DumpRawDir, when set, receives the raw JSON of every fetched issue as KEY.json, for
+	// troubleshooting field extraction
+	DumpRawDir string
+
+	// Stats counts the JIRA API calls made by the client (optional)
+	Stats *RunStats
+
+	// Verbose logs every JIRA request and the number of issues returned to stderr
+	Verbose bool
+
+	// UserAgent is sent with every JIRA request so admins can attribute the traffic,
+	// DefaultUserAgent("dev") when empty
+	UserAgent string
+
+	// Headers are added to every JIRA request, for proxies or gateways in front of JIRA
+	// (e.g. X-Atlassian-Token or a gateway auth header)
+	Headers http.Header
+
+	// LinkTemplate builds each ticket's link from the {base} URL, {key} and {project}, for
+	// deep links or instances with unusual URL schemes (default: DefaultLinkTemplate)
+	LinkTemplate string
+}
+
+// DefaultWorkers is the default JiraClientOptions.Workers: tickets are fetched one at a time
+const DefaultWorkers = 1
+
+// ToolName identifies this tool in the user-agent of JIRA requests
+const ToolName = "jira-helper"
+
+// DefaultUserAgent returns the user-agent sent to JIRA by this version of the tool
+func DefaultUserAgent(version string) string {
+	return fmt.Sprintf("%s/%s (evidence-integration)", ToolName, getOrDefault(version, "dev"))
+}
+
+// NewJiraClient creates a new JIRA client with authentication
+func NewJiraClient(options JiraClientOptions) (*JiraClient, error) {
+	jiraToken := getOrDefault(os.Getenv("JIRA_API_TOKEN"), options.Token)
+	if jiraToken == "" {
+		return nil, &ValidationError{Field: "JIRA_API_TOKEN", Value: "", Err: fmt.Errorf("environment variable not found")}
+	}
+
+	jiraURL := getOrDefault(os.Getenv("JIRA_URL"), options.URL)
+	if jiraURL == "" {
+		return nil, &ValidationError{Field: "JIRA_URL", Value: "", Err: fmt.Errorf("environment variable not found")}
+	}
+
+	jiraUsername := getOrDefault(os.Getenv("JIRA_USERNAME"), options.Username)
+	if jiraUsername == "" {
+		return nil, &ValidationError{Field: "JIRA_USERNAME", Value: "", Err: fmt.Errorf("environment variable not found")}
+	}
+
+	transport, err := newHTTPTransport(options)
+	if err != nil {
+		return nil, err
+	}
+
+	var roundTripper http.RoundTripper = transport
+	if options.DumpRawDir != "" {
+		if roundTripper, err = newRawDumpTransport(roundTripper, options.DumpRawDir); err != nil {
+			return nil, err
+		}
+	}
+	if len(options.Headers) > 0 {
+		roundTripper = &headerTransport{base: roundTripper, headers: options.Headers}
+	}
+	roundTripper = &userAgentTransport{
+		base:      roundTripper,
+		userAgent: getOrDefault(options.UserAgent, DefaultUserAgent("")),
+	}
+	if options.Stats != nil {
+		roundTripper = &countingTransport{base: roundTripper, stats: options.Stats}
+	}
+	if options.Verbose {
+		roundTripper = &verboseTransport{base: roundTripper}
+	}
+
+	// Create JIRA client with basic auth transport
+	tp := jira.BasicAuthTransport{
+		Username:  jiraUsername,
+		APIToken:  jiraToken,
+		Transport: roundTripper,
+	}
+
+	client, err := jira.NewClient(jiraURL, tp.Client())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create JIRA client: %w", err)
+	}
+
+	jc := NewJiraClientWithIssues(client.Issue, jiraURL, options)
+	jc.projects = &projectStatusService{client: client}
+	return jc, nil
+}
+
+// NewJiraClientWithIssues creates a JIRA client that fetches through the given issue API,
+// with links built on baseURL (for testing)
+func NewJiraClientWithIssues(issues IssueGetter, baseURL string, options JiraClientOptions) *JiraClient {
+	return &JiraClient{
+		issues:  issues,
+		baseURL: baseURL,
+		options: options,
+	}
+}
+
+// userAgentTransport sets the user-agent on every JIRA request, replacing the go-jira default
+type userAgentTransport struct {
+	base      http.RoundTripper
+	userAgent string
+}
+
+func (t *userAgentTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	return t.base.RoundTrip(req)
+}
+
+// headerTransport adds the configured headers (--header) to every JIRA request
+type headerTransport struct {
+	base    http.RoundTripper
+	headers http.Header
+}
+
+func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	for name, values := range t.headers {
+		req.Header[name] = append([]string(nil), values...)
+	}
+	return t.base.RoundTrip(req)
+}
+
+// verboseTransport logs each JIRA request and its response status for --verbose, with the
+// protocol (HTTP/1.1 or HTTP/2.0) and whether an open connection was reused
+type verboseTransport struct {
+	base http.RoundTripper
+}
+
+func (t *verboseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	PrintVerbose("JIRA %s %s", req.Method, req.URL.Redacted())
+	connection := "new"
+	trace := &httptrace.ClientTrace{
+		GotConn: func(info httptrace.GotConnInfo) {
+			if info.Reused {
+				connection = "reused"
+			}
+		},
+	}
+	resp, err := t.base.RoundTrip(req.WithContext(httptrace.WithClientTrace(req.Context(), trace)))
+	if err != nil {
+		PrintVerbose("JIRA request failed: %v", err)
+		return resp, err
+	}
+	PrintVerbose("JIRA responded %s (%s, %s connection)", resp.Status, resp.Proto, connection)
+	return resp, nil
+}
+
+// newHTTPTransport creates the HTTP transport used for JIRA requests
+func newHTTPTransport(options JiraClientOptions) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+
+	// Every request goes to the JIRA host, so the per-host limit is the one that matters. No more
+	// connections than there are workers are ever busy at the same time.
+	transport.MaxIdleConnsPerHost = max(options.Workers, http.DefaultMaxIdleConnsPerHost)
+	if options.MaxIdleConns > 0 {
+		transport.MaxIdleConnsPerHost = options.MaxIdleConns
+	}
+	if transport.MaxIdleConns < transport.MaxIdleConnsPerHost {
+		transport.MaxIdleConns = transport.MaxIdleConnsPerHost
+	}
+	if options.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = options.IdleConnTimeout
+	}
+	transport.DisableKeepAlives = options.DisableKeepAlives
+
+	// Negotiate HTTP/2 where JIRA supports it (JIRA Cloud does), so the requests of concurrent
+	// workers share one multiplexed connection. net/http skips HTTP/2 on a transport with a custom TLS config, as
+	// set by the CA and --insecure options, unless it is forced; DefaultTransport forces it too,
+	// but the clone must not depend on that.
+	transport.ForceAttemptHTTP2 = true
+
+	if options.ProxyURL != "" {
+		proxyURL, err := url.Parse(options.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, &ValidationError{Field: "proxy", Value: options.ProxyURL, Err: fmt.Errorf("invalid proxy URL")}
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if options.CACertFile != "" {
+		rootCAs, err := loadCACertPool(options.CACertFile)
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: rootCAs}
+	}
+
+	if options.InsecureSkipVerify {
+		PrintWarning("TLS certificate verification is DISABLED for JIRA requests; prefer JIRA_CA_CERT to trust a custom CA")
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+
+	return transport, nil
+}
+
+// loadCACertPool returns the system certificate pool extended with the CAs from a PEM file
+func loadCACertPool(caCertFile string) (*x509.CertPool, error) {
+	pemData, err := os.ReadFile(caCertFile)
+	if err != nil {
+		return nil, &ValidationError{Field: "JIRA_CA_CERT", Value: caCertFile, Err: err}
+	}
+
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+
+	if !pool.AppendCertsFromPEM(pemData) {
+		return nil, &ValidationError{Field: "JIRA_CA_CERT", Value: caCertFile, Err: fmt.Errorf("no PEM certificates found")}
+	}
+
+	return pool, nil
+}
+
+// Batch fetching settings: above batchFetchThreshold IDs, issues are fetched with
+// "key in (...)" searches of up to batchFetchSize keys instead of one request per ticket
+const (
+	batchFetchThreshold = 3
+	batchFetchSize      = 50
+)
+
+// FetchJiraDetails fetches JIRA details, batching them through the search endpoint when there are many IDs
+func (jc *JiraClient) FetchJiraDetails(jiraIDs []string) TransitionCheckResponse {
+	response, _ := jc.FetchJiraDetailsContext(context.Background(), jiraIDs)
+	return response
+}
+
+// FetchJiraDetailsContext is FetchJiraDetails with cancellation. Results are accumulated as
+// tickets are fetched; when ctx is cancelled it stops and returns the tasks fetched so far
+// together with ctx's error, so callers can save partial output.
+func (jc *JiraClient) FetchJiraDetailsContext(ctx context.Context, jiraIDs []string) (TransitionCheckResponse, error) {
+	jiraIDs, referencedAs := resolveKeyAliases(jiraIDs, jc.options.KeyAliases)
+
+	response := TransitionCheckResponse{
+		Tasks: make([]JiraTransitionResult, 0, len(jiraIDs)),
+	}
+
+	if err := jc.fetchInto(ctx, &response, jiraIDs, referencedAs); err != nil {
+		return response, err
+	}
+
+	if jc.options.IncludeSubtasks {
+		if err := jc.fetchSubtasks(ctx, &response); err != nil {
+			return response, err
+		}
+	}
+
+	return response, nil
+}
+
+// fetchInto fetches the JIRA IDs and appends their results to the response, stopping with ctx's
+// error when ctx is cancelled. Up to Workers tickets are fetched at the same time; each result is
+// written to its own index, so the results keep the order of the JIRA IDs whatever the number of workers.
+func (jc *JiraClient) fetchInto(ctx context.Context, response *TransitionCheckResponse, jiraIDs []string, referencedAs map[string]string) error {
+	var batched map[string]*jira.Issue
+	if len(jiraIDs) > batchFetchThreshold {
+		batched = jc.searchIssuesByKey(ctx, jiraIDs)
+	}
+
+	results := make([]JiraTransitionResult, len(jiraIDs))
+	fetched := make([]bool, len(jiraIDs))
+
+	workers := jc.options.Workers
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				jiraID := jiraIDs[i]
+
+				// IDs missing from the search results (deleted, moved, no permission) are fetched
+				// one by one so they still get a proper error result
+				var result JiraTransitionResult
+				if issue, ok := batched[strings.ToUpper(jiraID)]; ok {
+					result = jc.createSuccessResult(issue)
+				} else {
+					result = jc.fetchSingleJiraDetail(ctx, jiraID)
+					// A request aborted by the cancellation is not a real error result
+					if ctx.Err() != nil {
+						continue
+					}
+				}
+				result.ReferencedAs = referencedAs[jiraID]
+				results[i] = result
+				fetched[i] = true
+			}
+		}()
+	}
+
+feed:
+	for i := range jiraIDs {
+		if ctx.Err() != nil {
+			break
+		}
+		select {
+		case indexes <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(indexes)
+	wg.Wait()
+
+	// On cancellation only the tickets fetched before the first missing one are kept, in order
+	for i, result := range results {
+		if !fetched[i] {
+			return ctx.Err()
+		}
+		response.Tasks = append(response.Tasks, result)
+	}
+
+	return nil
+}
+
+// fetchSubtasks appends the subtasks of the fetched tasks, and the subtasks of those in turn.
+// Keys already in the response are skipped, so a ticket is fetched once even if it is referenced
+// directly or the hierarchy loops.
+func (jc *JiraClient) fetchSubtasks(ctx context.Context, response *TransitionCheckResponse) error {
+	seen := make(map[string]bool, len(response.Tasks))
+	for _, task := range response.Tasks {
+		seen[strings.ToUpper(task.Key)] = true
+	}
+
+	// response.Tasks grows while it is walked, which visits the subtasks' subtasks too
+	for i := 0; i < len(response.Tasks); i++ {
+		parent := response.Tasks[i].Key
+
+		var subtaskKeys []string
+		for _, key := range response.Tasks[i].Subtasks {
+			if !seen[strings.ToUpper(key)] {
+				seen[strings.ToUpper(key)] = true
+				subtaskKeys = append(subtaskKeys, key)
+			}
+		}
+		if len(subtaskKeys) == 0 {
+			continue
+		}
+
+		first := len(response.Tasks)
+		if err := jc.fetchInto(ctx, response, subtaskKeys, nil); err != nil {
+			return err
+		}
+		for j := first; j < len(response.Tasks); j++ {
+			response.Tasks[j].Parent = parent
+		}
+	}
+
+	return nil
+}
+
+// resolveKeyAliases rewrites IDs of renamed projects to their current key, dropping IDs that
+// resolve to a ticket already in the list. It returns the rewritten IDs and, for each rewritten ID,
+// the original key it was referenced by.
+func resolveKeyAliases(jiraIDs []string, aliases map[string]string) ([]string, map[string]string) {
+	if len(aliases) == 0 {
+		return jiraIDs, nil
+	}
+
+	resolved := make([]string, 0, len(jiraIDs))
+	referencedAs := make(map[string]string)
+	seen := make(map[string]bool, len(jiraIDs))
+	for _, jiraID := range jiraIDs {
+		target := jiraID
+		if project, number, found := strings.Cut(jiraID, "-"); found {
+			if newProject, ok := aliases[strings.ToUpper(project)]; ok {
+				target = newProject + "-" + number
+			}
+		}
+
+		if seen[strings.ToUpper(target)] {
+			continue
+		}
+		seen[strings.ToUpper(target)] = true
+		resolved = append(resolved, target)
+		if target != jiraID {
+			referencedAs[target] = jiraID
+		}
+	}
+	return resolved, referencedAs
+}
+
+// searchIssuesByKey fetches issues with changelog in chunks of batchFetchSize keys.
+// Failed chunks are skipped; their IDs fall back to individual requests.
+func (jc *JiraClient) searchIssuesByKey(ctx context.Context, jiraIDs []string) map[string]*jira.Issue {
+	issues := make(map[string]*jira.Issue, len(jiraIDs))
+
+	for start := 0; start < len(jiraIDs); start += batchFetchSize {
+		end := start + batchFetchSize
+		if end > len(jiraIDs) {
+			end = len(jiraIDs)
+		}
+		chunk := jiraIDs[start:end]
+
+		quoted := make([]string, len(chunk))
+		for i, jiraID := range chunk {
+			quoted[i] = strconv.Quote(jiraID)
+		}
+		jql := fmt.Sprintf("key in (%s)", strings.Join(quoted, ","))
+
+		// validateQuery=warn keeps unknown keys from failing the whole chunk
+		results, _, err := jc.issues.Search(ctx, jql, &jira.SearchOptions{
+			MaxResults:    len(chunk),
+			Expand:        "changelog",
+			Fields:        jc.requestedFields(),
+			ValidateQuery: "warn",
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return issues
+			}
+			PrintWarning("Batch fetch failed, falling back to individual requests: %v", err)
+			continue
+		}
+
+		if jc.options.Verbose {
+			PrintVerbose("Batch search returned %d of %d issues", len(results), len(chunk))
+		}
+
+		for i := range results {
+			if results[i].Fields != nil {
+				issues[strings.ToUpper(results[i].Key)] = &results[i]
+			}
+		}
+	}
+
+	return issues
+}
+
+// jqlSearchPageSize is the number of issues requested per JQL search page
+const jqlSearchPageSize = 100
+
+// SearchJiraIDs returns the keys of all issues matching a JQL query, following pagination
+func (jc *JiraClient) SearchJiraIDs(jql string) ([]string, error) {
+	var jiraIDs []string
+
+	options := &jira.SearchOptions{MaxResults: jqlSearchPageSize, Fields: []string{"key"}}
+	err := jc.issues.SearchPages(context.Background(), jql, options, func(issue jira.Issue) error {
+		jiraIDs = append(jiraIDs, issue.Key)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("JQL search failed: %w", err)
+	}
+
+	if jc.options.Verbose {
+		PrintVerbose("JQL search returned %d issues", len(jiraIDs))
+	}
+
+	return jiraIDs, nil
+}
+
+// fetchSingleJiraDetail fetches details for a single JIRA ID
+func (jc *JiraClient) fetchSingleJiraDetail(ctx context.Context, jiraID string) JiraTransitionResult {
+	issue, resp, err := jc.getIssue(ctx, jiraID, "changelog")
+
+	// JIRA Cloud answers 429 when the rate limit is exceeded and says when to come back.
+	// This single wait is separate from any other retrying.
+	if getHTTPStatusCode(resp) == http.StatusTooManyRequests {
+		wait := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		PrintWarning("JIRA rate limit reached while fetching %s, waiting %s before retrying", jiraID, wait)
+		jc.options.Stats.recordRetry()
+		if sleepErr := rateLimitSleep(ctx, wait); sleepErr != nil {
+			return jc.createErrorResult(&JiraError{Key: jiraID, StatusCode: http.StatusTooManyRequests, Err: sleepErr})
+		}
+		issue, resp, err = jc.getIssue(ctx, jiraID, "changelog")
+	}
+
+	// Some permission setups reject the changelog expand although the issue itself is readable;
+	// the ticket is then recorded without its transitions rather than as an error. Other refusals
+	// (no permission on the issue) are not retried.
+	if status := getHTTPStatusCode(resp); err != nil && (status == http.StatusBadRequest || status == http.StatusForbidden) && mentionsChangelog(err) {
+		plain, plainResp, plainErr := jc.getIssue(ctx, jiraID, "")
+		if plainErr == nil && plain != nil && plain.Fields != nil {
+			PrintWarning("JIRA refused the change history of %s (HTTP %d), recording it without transitions", jiraID, status)
+			issue, resp, err = plain, plainResp, nil
+		}
+	}
+
+	if err != nil || issue == nil || issue.Fields == nil {
+		return jc.createErrorResult(&JiraError{Key: jiraID, StatusCode: getHTTPStatusCode(resp), Err: err})
+	}
+
+	result := jc.createSuccessResult(issue)
+	// JIRA redirects the key of a ticket moved to another project to its new key
+	if !strings.EqualFold(result.Key, jiraID) {
+		PrintWarning("%s was moved and is now %s, recording it under the new key", jiraID, result.Key)
+		result.RequestedKey = jiraID
+	}
+	return result
+}
+
+// mentionsChangelog reports whether a refused fetch is about the changelog expand, going by the
+// error JIRA answered with, which go-jira carries in err
+func mentionsChangelog(err error) bool {
+	text := err.Error()
+	var jiraErr *jira.Error
+	if errors.As(err, &jiraErr) {
+		text = jiraErr.LongError()
+	}
+	text = strings.ToLower(text)
+	return strings.Contains(text, "changelog") || strings.Contains(text, "expand")
+}
+
+// slowFetchThreshold flags single-ticket fetches in --verbose, typically tickets with enormous changelogs
+var slowFetchThreshold = 2 * time.Second
+
+// issueFields are the JIRA fields createSuccessResult reads; only these are requested, since
+// fetching every field makes large issues much slower to transfer
+var issueFields = []string{
+	"status", "issuetype", "project", "created", "updated", "assignee", "reporter", "priority",
+	"description", "resolution", "resolutiondate", "security",
+}
+
+// requestedFields returns the fields to request for each issue: issueFields plus the fields of
+// the enabled --include-* options
+func (jc *JiraClient) requestedFields() []string {
+	// The sprint field is a custom field whose ID differs between instances
+	if jc.options.IncludeSprints {
+		return []string{"*all"}
+	}
+
+	fields := append([]string{}, issueFields...)
+	if jc.options.IncludeLinks {
+		fields = append(fields, "issuelinks")
+	}
+	if jc.options.IncludeSubtasks {
+		fields = append(fields, "subtasks")
+	}
+	return fields
+}
+
+// getIssue fetches an issue's requestedFields with the given expand (e.g. "changelog", "" for
+// none), timing the request for --verbose and the run summary
+func (jc *JiraClient) getIssue(ctx context.Context, jiraID, expand string) (*jira.Issue, *jira.Response, error) {
+	options := &jira.GetQueryOptions{
+		Fields: strings.Join(jc.requestedFields(), ","),
+		Expand: expand,
+	}
+
+	start := time.Now()
+	issue, resp, err := jc.issues.Get(ctx, jiraID, options)
+	elapsed := time.Since(start)
+
+	jc.options.Stats.recordFetch(jiraID, elapsed)
+	if jc.options.Verbose {
+		if elapsed > slowFetchThreshold {
+			PrintVerbose("Fetched %s in %s (slow, over %s)", jiraID, elapsed.Round(time.Millisecond), slowFetchThreshold)
+		} else {
+			PrintVerbose("Fetched %s in %s", jiraID, elapsed.Round(time.Millisecond))
+		}
+	}
+	return issue, resp, err
+}
+
+// Waits for a 429 response without a usable Retry-After header, and the longest wait honored
+const (
+	defaultRetryAfter = 5 * time.Second
+	maxRetryAfter     = 5 * time.Minute
+)
+
+// rateLimitSleep waits for d or until ctx is cancelled; tests replace it to avoid real waits
+var rateLimitSleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// parseRetryAfter returns how long a Retry-After header asks to wait. The header holds either
+// a number of seconds or an HTTP date; a missing or unparsable value waits defaultRetryAfter,
+// and waits are capped at maxRetryAfter so a bogus header can't stall the run.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+
+	wait := defaultRetryAfter
+	if seconds, err := strconv.Atoi(value); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	} else if date, err := http.ParseTime(value); err == nil {
+		wait = date.Sub(now)
+	}
+
+	if wait < 0 {
+		return 0
+	}
+	if wait > maxRetryAfter {
+		return maxRetryAfter
+	}
+	return wait
+}
+
+// createErrorResult creates an error result for a failed JIRA fetch.
+// jiraErr.Err may be nil when JIRA answered without an error but also without the issue.
+func (jc *JiraClient) createErrorResult(jiraErr *JiraError) JiraTransitionResult {
+	errorPrefix := "Error"
+	if jiraErr.StatusCode != 0 {
+		errorPrefix = fmt.Sprintf("Error %d", jiraErr.StatusCode)
+	}
+
+	errorMsg := fmt.Sprintf("%s: Could not retrieve issue", errorPrefix)
+	if jiraErr.Err != nil {
+		errorMsg = fmt.Sprintf("%s: %v", errorPrefix, jiraErr.Err)
+		PrintError("Failed to fetch JIRA %s: %v", jiraErr.Key, jiraErr.Err)
+	}
+
+	return JiraTransitionResult{
+		Key:         jiraErr.Key,
+		Link:        "", // No link for error results
+		Status:      ErrorStatus,
+		ErrorCode:   jiraErr.StatusCode,
+		Description: errorMsg,
+		Type:        ErrorType,
+		Project:     "",
+		Created:     "",
+		Updated:     "",
+		Assignee:    nil,
+		Reporter:    "",
+		Priority:    "",
+		Transitions: []Transition{},
+	}
+}
+
+// createSuccessResult creates a result from a successfully fetched JIRA issue.
+// Missing fields are left empty; a ticket without a status or type gets a warning, since that
+// points at a partial API response (e.g. a restricted field configuration) rather than real data.
+func (jc *JiraClient) createSuccessResult(issue *jira.Issue) JiraTransitionResult {
+	if issue.Fields == nil {
+		return jc.createErrorResult(&JiraError{Key: issue.Key, Err: fmt.Errorf("JIRA returned the issue without fields")})
+	}
+
+	// Create the JIRA link
+	link := ""
+	if jc.baseURL != "" {
+		link = issueLink(jc.options.LinkTemplate, jc.baseURL, issue.Key, getProjectKey(issue.Fields.Project))
+	}
+
+	result := JiraTransitionResult{
+		Key:            issue.Key,
+		Link:           link,
+		Status:         getStatusName(issue.Fields.Status),
+		StatusCategory: getStatusCategory(issue.Fields.Status),
+		Description:    truncateDescription(getDescription(issue.Fields.Description), jc.options.MaxDescription),
+		Type:           getIssueTypeName(issue.Fields.Type),
+		Project:        getProjectKey(issue.Fields.Project),
+		Created:        getTimeAsString(issue.Fields.Created),
+		Updated:        getTimeAsString(issue.Fields.Updated),
+		Assignee:       getAssignee(issue.Fields.Assignee),
+		Reporter:       getReporterName(issue.Fields.Reporter),
+		Priority:       getPriorityName(issue.Fields.Priority),
+		Resolution:     getResolutionName(issue.Fields.Resolution),
+		ResolutionDate: getTimeAsString(issue.Fields.Resolutiondate),
+		SecurityLevel:  getSecurityLevel(issue.Fields.Unknowns),
+		Transitions:    jc.extractTransitions(issue),
+	}
+
+	var missing []string
+	if result.Status == "" {
+		missing = append(missing, "status")
+	}
+	if result.Type == "" {
+		missing = append(missing, "type")
+	}
+	if len(missing) > 0 {
+		PrintWarning("JIRA returned %s without %s, the response may be incomplete", issue.Key, strings.Join(missing, " or "))
+	}
+
+	if jc.options.IncludeSprints {
+		result.Sprints = getSprintNames(issue.Fields.Unknowns)
+	}
+
+	if jc.options.IncludeLinks {
+		result.Links = getIssueLinks(issue.Fields.IssueLinks)
+	}
+
+	if jc.options.IncludeSubtasks {
+		for _, subtask := range issue.Fields.Subtasks {
+			if subtask != nil && subtask.Key != "" {
+				result.Subtasks = append(result.Subtasks, subtask.Key)
+			}
+		}
+	}
+
+	if jc.options.IncludeUserIDs {
+		result.AssigneeID = getUserID(issue.Fields.Assignee)
+		result.ReporterID = getUserID(issue.Fields.Reporter)
+	}
+
+	return result
+}
+
+// extractTransitions extracts status transitions from issue changelog.
+// No-op changes (X to X, typically from bulk edits) are dropped, and a transition that repeats
+// the previous one identically is collapsed into it.
+func (jc *JiraClient) extractTransitions(issue *jira.Issue) []Transition {
+	var transitions []Transition
+
+	if issue.Changelog == nil || len(issue.Changelog.Histories) == 0 {
+		return transitions
+	}
+
+	for _, history := range issue.Changelog.Histories {
+		for _, item := range history.Items {
+			if item.Field == "status" {
+				if item.FromString == item.ToString {
+					continue
+				}
+				if n := len(transitions); jc.options.CollapseRepeatedTransitions && n > 0 && transitions[n-1].FromStatus == item.FromString && transitions[n-1].ToStatus == item.ToString {
+					continue
+				}
+				transition := Transition{
+					FromStatus:     item.FromString,
+					ToStatus:       item.ToString,
+					Author:         history.Author.DisplayName,
+					AuthorEmail:    history.Author.EmailAddress,
+					TransitionTime: history.Created,
+				}
+				transitions = append(transitions, transition)
+			}
+		}
+	}
+
+	return transitions
+}
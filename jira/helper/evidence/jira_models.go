@@ -0,0 +1,193 @@
+package evidence
+
+// Constants for JIRA operations
+const (
+	JiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+	ErrorStatus    = "Error"
+	ErrorType      = "Error"
+
+	LinkOutward = "outward"
+	LinkInward  = "inward"
+)
+
+/*
+    TransitionCheckResponse is the json formatted predicate that will be returned to the calling build process for creating an evidence
+    its structure should be:
+
+    {
+        "meta": {
+            "generated_at": "2020-01-02T10:00:00Z",
+            "tool_version": "v1.4.0",
+            "schema_version": 1,
+            "source": {
+                "repository": "https://github.com/example/app.git",
+                "branch": "main",
+                "commit": "0a1b2c3d4e5f60718293a4b5c6d7e8f901234567"
+            }
+        },
+        "tasks": [
+            {
+                "key": "EV-1",
+                "status": "QA in Progress",
+                "status_category": "In Progress",
+                "description": "<description text>",
+                "type": "Task",
+                "project": "EV",
+                "created": "2020-01-01T12:11:56.063+0530",
+                "updated": "2020-01-01T12:12:01.876+0530",
+                "assignee": "<assignee name>",
+                "reporter": "<reporter name>",
+                "priority": "Medium",
+                "resolution": "",
+                "resolution_date": "",
+                "security_level": "",
+                "transitions": [
+                    {
+                        "from_status": "To Do",
+                        "to_status": "In Progress",
+                        "author": "<>author name>",
+                        "author_user_name": "<author email>",
+                        "transition_time": "2020-07-28T16:39:54.620+0530"
+                    }
+                ]
+            },
+            {
+                "key": "EV-2",
+                "status": "Error",
+                "error_code": 404,
+                "description": "Error 404: Could not retrieve issue",
+                "type": "Error",
+                "project": "",
+                "created": "",
+                "updated": "",
+                "assignee": null,
+                "reporter": "",
+                "priority": "",
+                "resolution": "",
+                "resolution_date": "",
+                "security_level": "",
+                "transitions": []
+            }
+        ]
+    }
+
+   resolution and resolution_date are empty while the ticket is unresolved, e.g. "Fixed" and
+   "2020-07-30T09:12:44.000+0530" once it is resolved. A ticket can be in a done-like status
+   without having been resolved.
+
+   status_category is the category JIRA groups the status under: "To Do", "In Progress" or "Done".
+   It is absent for error results, for statuses without a category and in files written by
+   versions before it was added.
+
+   security_level is the name of the ticket's issue security level (e.g. "Internal"), empty when
+   the ticket is visible to everyone who can browse the project.
+
+   assignee_id and reporter_id are only present with --include-user-ids. They hold the user's email,
+   or the accountId when JIRA Cloud hides the email address.
+
+   meta is written by the jira-helper command and may be absent from older files; consumers that
+   only read tasks can ignore it. generated_at is UTC in RFC 3339 format.
+
+   links is only present with --include-links. Each entry has the link type name (e.g. "Blocks"),
+   the direction ("outward" when this ticket is the source, "inward" when it is the target), the
+   relation as JIRA words it for that direction (e.g. "blocks" / "is blocked by") and the linked key.
+
+   subtasks and parent are only present with --include-subtasks. subtasks lists the keys of the
+   ticket's subtasks, which are appended to tasks after the tickets that were asked for, each with
+   parent set to the key of the ticket it was fetched for.
+
+   referenced_as is only present when a --key-alias rewrote the key; it holds the former key
+   (e.g. OLD-123) the commits referenced the ticket by.
+
+   requested_key is only present when JIRA answered with a different key than the one requested,
+   which happens when the ticket was moved to another project: key is the ticket's current key and
+   requested_key the key it was fetched by.
+
+   error_code holds the HTTP status JIRA answered with (e.g. 404 for a missing ticket, 403 for
+   no permission) and is omitted when no response was received, such as on network failures.
+
+   notice that the calling client should first check that return value was 0 before using the response JSON,
+   otherwise the response is an error message which cannot be parsed
+*/
+
+type TransitionCheckResponse struct {
+	Meta  *ResponseMeta          `json:"meta,omitempty"`
+	Tasks []JiraTransitionResult `json:"tasks"`
+}
+
+// SchemaVersion is the version of the evidence JSON layout, bumped on incompatible changes
+const SchemaVersion = 1
+
+// ResponseMeta records how an evidence file was produced, so archived files remain interpretable
+type ResponseMeta struct {
+	GeneratedAt   string `json:"generated_at"`
+	ToolVersion   string `json:"tool_version"`
+	SchemaVersion int    `json:"schema_version"`
+	// Source is the git checkout the JIRA IDs were extracted from, absent for direct IDs and --jql
+	Source *SourceInfo `json:"source,omitempty"`
+	// Partial marks the output of an interrupted run, which lacks the tickets not fetched yet
+	Partial bool `json:"partial,omitempty"`
+}
+
+// SourceInfo identifies the repository, branch and commit an evidence file was produced from
+type SourceInfo struct {
+	// Repository is the origin remote URL, empty when the repository has no origin
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+	Commit     string `json:"commit"`
+}
+
+type JiraTransitionResult struct {
+	Key            string       `json:"key"`
+	ReferencedAs   string       `json:"referenced_as,omitempty"`
+	RequestedKey   string       `json:"requested_key,omitempty"`
+	Link           string       `json:"link,omitempty"`
+	Status         string       `json:"status"`
+	StatusCategory string       `json:"status_category,omitempty"`
+	ErrorCode      int          `json:"error_code,omitempty"`
+	Description    string       `json:"description"`
+	Type           string       `json:"type"`
+	Project        string       `json:"project"`
+	Created        string       `json:"created"`
+	Updated        string       `json:"updated"`
+	Assignee       *string      `json:"assignee"`
+	AssigneeID     string       `json:"assignee_id,omitempty"`
+	Reporter       string       `json:"reporter"`
+	ReporterID     string       `json:"reporter_id,omitempty"`
+	Priority       string       `json:"priority"`
+	Resolution     string       `json:"resolution"`
+	ResolutionDate string       `json:"resolution_date"`
+	SecurityLevel  string       `json:"security_level"`
+	Sprints        []string     `json:"sprints,omitempty"`
+	Links          []IssueLink  `json:"links,omitempty"`
+	Parent         string       `json:"parent,omitempty"`
+	Subtasks       []string     `json:"subtasks,omitempty"`
+	Commits        []CommitRef  `json:"commits,omitempty"`
+	Transitions    []Transition `json:"transitions"`
+}
+
+// CommitRef is a commit whose message referenced the ticket, recorded with --with-commit-meta.
+// Date is the author date in strict ISO 8601 as printed by git (%aI).
+type CommitRef struct {
+	Hash        string `json:"hash"`
+	Author      string `json:"author"`
+	AuthorEmail string `json:"author_email"`
+	Date        string `json:"date"`
+}
+
+// IssueLink is a link from the ticket to another issue, e.g. "blocks EV-2" (outward) or
+// "is blocked by EV-3" (inward). Relation is the link type's wording for that direction.
+type IssueLink struct {
+	Type      string `json:"type"`
+	Direction string `json:"direction"`
+	Relation  string `json:"relation"`
+	Key       string `json:"key"`
+}
+
+type Transition struct {
+	FromStatus     string `json:"from_status"`
+	ToStatus       string `json:"to_status"`
+	Author         string `json:"author"`
+	AuthorEmail    string `json:"author_user_name"`
+	TransitionTime string `json:"transition_time"`
+}
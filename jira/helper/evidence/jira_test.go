@@ -0,0 +1,543 @@
+package evidence
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira/v2/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockJiraClient is a mock implementation of the JIRA client
+type MockJiraClient struct {
+	mock.Mock
+}
+
+// MockIssueService mocks the JIRA issue service
+type MockIssueService struct {
+	mock.Mock
+}
+
+var _ IssueGetter = (*MockIssueService)(nil)
+
+func (m *MockIssueService) Get(ctx context.Context, issueID string, options *jira.GetQueryOptions) (*jira.Issue, *jira.Response, error) {
+	args := m.Called(ctx, issueID, options)
+	issue, _ := args.Get(0).(*jira.Issue)
+	resp, _ := args.Get(1).(*jira.Response)
+	return issue, resp, args.Error(2)
+}
+
+func (m *MockIssueService) Search(ctx context.Context, jql string, options *jira.SearchOptions) ([]jira.Issue, *jira.Response, error) {
+	args := m.Called(ctx, jql, options)
+	issues, _ := args.Get(0).([]jira.Issue)
+	resp, _ := args.Get(1).(*jira.Response)
+	return issues, resp, args.Error(2)
+}
+
+func (m *MockIssueService) SearchPages(ctx context.Context, jql string, options *jira.SearchOptions, f func(jira.Issue) error) error {
+	args := m.Called(ctx, jql, options, f)
+	if issues, ok := args.Get(0).([]jira.Issue); ok {
+		for _, issue := range issues {
+			if err := f(issue); err != nil {
+				return err
+			}
+		}
+	}
+	return args.Error(1)
+}
+
+func TestTruncateDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		desc     string
+		max      int
+		expected string
+	}{
+		{name: "No limit", desc: "A long description", max: 0, expected: "A long description"},
+		{name: "Short description is kept", desc: "Short", max: 10, expected: "Short"},
+		{name: "Exact length is kept", desc: "Exactly 10", max: 10, expected: "Exactly 10"},
+		{name: "Long description is cut", desc: "A long description", max: 6, expected: "A long…"},
+		{name: "Whitespace before the ellipsis is dropped", desc: "First paragraph\n\nSecond paragraph", max: 17, expected: "First paragraph…"},
+		{name: "Characters are counted, not bytes", desc: "Café crème brûlée", max: 10, expected: "Café crème…"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, truncateDescription(tt.desc, tt.max))
+		})
+	}
+}
+
+// Test getDescription function
+func TestGetDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "nil input",
+			input:    nil,
+			expected: "",
+		},
+		{
+			name:     "string input",
+			input:    "simple string",
+			expected: "simple string",
+		},
+		{
+			name: "ADF format with text",
+			input: map[string]interface{}{
+				"content": []interface{}{
+					map[string]interface{}{
+						"type": "paragraph",
+						"content": []interface{}{
+							map[string]interface{}{
+								"type": "text",
+								"text": "Test description",
+							},
+						},
+					},
+				},
+			},
+			expected: "Test description",
+		},
+		{
+			name: "ADF format with multiple paragraphs",
+			input: map[string]interface{}{
+				"content": []interface{}{
+					map[string]interface{}{
+						"type": "paragraph",
+						"content": []interface{}{
+							map[string]interface{}{
+								"type": "text",
+								"text": "First paragraph",
+							},
+						},
+					},
+					map[string]interface{}{
+						"type": "paragraph",
+						"content": []interface{}{
+							map[string]interface{}{
+								"type": "text",
+								"text": "Second paragraph",
+							},
+						},
+					},
+				},
+			},
+			expected: "First paragraph\n\nSecond paragraph",
+		},
+		{
+			name: "ADF format with blank and padded paragraphs",
+			input: map[string]interface{}{
+				"content": []interface{}{
+					map[string]interface{}{
+						"type": "paragraph",
+						"content": []interface{}{
+							map[string]interface{}{
+								"type": "text",
+								"text": "  Steps to reproduce: ",
+							},
+						},
+					},
+					map[string]interface{}{
+						"type": "paragraph",
+						"content": []interface{}{
+							map[string]interface{}{
+								"type": "text",
+								"text": " ",
+							},
+						},
+					},
+					map[string]interface{}{
+						"type": "paragraph",
+						"content": []interface{}{
+							map[string]interface{}{
+								"type": "text",
+								"text": "Open the ",
+							},
+							map[string]interface{}{
+								"type": "text",
+								"text": "login page\n",
+							},
+						},
+					},
+				},
+			},
+			expected: "Steps to reproduce:\n\nOpen the login page",
+		},
+		{
+			name:     "string input is trimmed",
+			input:    "\n  simple string \n",
+			expected: "simple string",
+		},
+		{
+			name:     "invalid ADF format",
+			input:    map[string]interface{}{"invalid": "format"},
+			expected: "map[invalid:format]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getDescription(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// Test extractTextFromADFNode function
+func TestExtractTextFromADFNode(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "nil input",
+			input:    nil,
+			expected: "",
+		},
+		{
+			name:     "non-map input",
+			input:    "string",
+			expected: "",
+		},
+		{
+			name: "text node",
+			input: map[string]interface{}{
+				"type": "text",
+				"text": "Hello World",
+			},
+			expected: "Hello World",
+		},
+		{
+			name: "paragraph node",
+			input: map[string]interface{}{
+				"type": "paragraph",
+				"content": []interface{}{
+					map[string]interface{}{
+						"type": "text",
+						"text": "Paragraph text",
+					},
+				},
+			},
+			expected: "Paragraph text",
+		},
+		{
+			name: "unknown node type",
+			input: map[string]interface{}{
+				"type": "unknown",
+			},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := extractTextFromADFNode(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// Test getTimeAsString function
+func TestGetTimeAsString(t *testing.T) {
+	now := time.Now()
+	nowPtr := &now
+	expectedFormat := now.Format("2006-01-02T15:04:05.000-0700")
+
+	tests := []struct {
+		name     string
+		input    interface{}
+		expected string
+	}{
+		{
+			name:     "nil input",
+			input:    nil,
+			expected: "",
+		},
+		{
+			name:     "string input",
+			input:    "2023-01-01T12:00:00.000+0000",
+			expected: "2023-01-01T12:00:00.000+0000",
+		},
+		{
+			name:     "time.Time input",
+			input:    now,
+			expected: expectedFormat,
+		},
+		{
+			name:     "*time.Time input",
+			input:    nowPtr,
+			expected: expectedFormat,
+		},
+		{
+			name:     "jira.Time input",
+			input:    jira.Time(now),
+			expected: expectedFormat,
+		},
+		{
+			name:     "zero jira.Time input",
+			input:    jira.Time{},
+			expected: "",
+		},
+		{
+			name:     "nil *jira.Time input",
+			input:    (*jira.Time)(nil),
+			expected: "",
+		},
+		{
+			name:     "zero time.Time input",
+			input:    time.Time{},
+			expected: "",
+		},
+		{
+			name:     "unknown type",
+			input:    123,
+			expected: "123",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := getTimeAsString(tt.input)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// Test field extractors
+func TestFieldExtractors(t *testing.T) {
+	t.Run("getStatusName", func(t *testing.T) {
+		// Test nil status
+		assert.Equal(t, "", getStatusName(nil))
+
+		// Test valid status
+		status := &jira.Status{Name: "In Progress"}
+		assert.Equal(t, "In Progress", getStatusName(status))
+	})
+
+	t.Run("getStatusCategory", func(t *testing.T) {
+		assert.Equal(t, "", getStatusCategory(nil))
+		assert.Equal(t, "", getStatusCategory(&jira.Status{Name: "Open"}))
+
+		// JIRA reports statuses without a category with the "undefined" key
+		undefined := &jira.Status{Name: "Open", StatusCategory: jira.StatusCategory{Key: "undefined", Name: "No Category"}}
+		assert.Equal(t, "", getStatusCategory(undefined))
+
+		status := &jira.Status{Name: "QA", StatusCategory: jira.StatusCategory{Key: "indeterminate", Name: "In Progress"}}
+		assert.Equal(t, "In Progress", getStatusCategory(status))
+	})
+
+	t.Run("getIssueTypeName", func(t *testing.T) {
+		issueType := jira.IssueType{Name: "Task"}
+		assert.Equal(t, "Task", getIssueTypeName(issueType))
+	})
+
+	t.Run("getProjectKey", func(t *testing.T) {
+		project := jira.Project{Key: "PROJ"}
+		assert.Equal(t, "PROJ", getProjectKey(project))
+	})
+
+	t.Run("getReporterName", func(t *testing.T) {
+		// Test nil reporter
+		assert.Equal(t, "", getReporterName(nil))
+
+		// Test valid reporter
+		reporter := &jira.User{DisplayName: "John Doe"}
+		assert.Equal(t, "John Doe", getReporterName(reporter))
+	})
+
+	t.Run("getPriorityName", func(t *testing.T) {
+		// Test nil priority
+		assert.Equal(t, "", getPriorityName(nil))
+
+		// Test valid priority
+		priority := &jira.Priority{Name: "High"}
+		assert.Equal(t, "High", getPriorityName(priority))
+	})
+
+	t.Run("getResolutionName", func(t *testing.T) {
+		// Test unresolved
+		assert.Equal(t, "", getResolutionName(nil))
+
+		// Test resolved
+		assert.Equal(t, "Fixed", getResolutionName(&jira.Resolution{Name: "Fixed"}))
+	})
+
+	t.Run("getSecurityLevel", func(t *testing.T) {
+		// Test no security level
+		assert.Equal(t, "", getSecurityLevel(nil))
+		assert.Equal(t, "", getSecurityLevel(map[string]interface{}{"security": nil}))
+
+		// Test restricted ticket
+		fields := map[string]interface{}{"security": map[string]interface{}{"id": "10000", "name": "Internal"}}
+		assert.Equal(t, "Internal", getSecurityLevel(fields))
+	})
+
+	t.Run("getUserID", func(t *testing.T) {
+		// Test nil user
+		assert.Equal(t, "", getUserID(nil))
+
+		// Email is preferred, accountId is the fallback
+		assert.Equal(t, "jane@example.com", getUserID(&jira.User{EmailAddress: "jane@example.com", AccountID: "abc"}))
+		assert.Equal(t, "abc", getUserID(&jira.User{AccountID: "abc"}))
+	})
+
+	t.Run("getAssignee", func(t *testing.T) {
+		// Test nil assignee
+		assert.Nil(t, getAssignee(nil))
+
+		// Test valid assignee
+		assignee := &jira.User{DisplayName: "Jane Doe"}
+		result := getAssignee(assignee)
+		assert.NotNil(t, result)
+		assert.Equal(t, "Jane Doe", *result)
+	})
+}
+
+// Test getSprintNames function
+func TestGetSprintNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    map[string]interface{}
+		expected []string
+	}{
+		{
+			name:     "nil custom fields",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name: "serialized sprint strings",
+			input: map[string]interface{}{
+				"customfield_10020": []interface{}{
+					"com.atlassian.greenhopper.service.sprint.Sprint@14b1c359[id=1,rapidViewId=2,state=CLOSED,name=Sprint 1,startDate=2023-01-01T00:00:00.000Z]",
+					"com.atlassian.greenhopper.service.sprint.Sprint@24b1c359[id=2,rapidViewId=2,state=ACTIVE,name=Sprint 2,startDate=2023-01-15T00:00:00.000Z]",
+				},
+			},
+			expected: []string{"Sprint 1", "Sprint 2"},
+		},
+		{
+			name: "cloud sprint objects",
+			input: map[string]interface{}{
+				"customfield_10020": []interface{}{
+					map[string]interface{}{"id": float64(7), "name": "EV Sprint 7", "state": "closed", "boardId": float64(3)},
+				},
+			},
+			expected: []string{"EV Sprint 7"},
+		},
+		{
+			name: "non-sprint custom fields are ignored",
+			input: map[string]interface{}{
+				"customfield_10001": []interface{}{"label-a", "label-b"},
+				"customfield_10002": "plain value",
+				"customfield_10003": []interface{}{map[string]interface{}{"name": "Component"}},
+			},
+			expected: nil,
+		},
+		{
+			name: "serialized sprint with empty name",
+			input: map[string]interface{}{
+				"customfield_10020": []interface{}{
+					"com.atlassian.greenhopper.service.sprint.Sprint@14b1c359[id=1,state=CLOSED,name=,goal=]",
+				},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getSprintNames(tt.input))
+		})
+	}
+}
+
+func TestGetIssueLinks(t *testing.T) {
+	blocks := jira.IssueLinkType{Name: "Blocks", Inward: "is blocked by", Outward: "blocks"}
+	relates := jira.IssueLinkType{Name: "Relates", Inward: "relates to", Outward: "relates to"}
+
+	tests := []struct {
+		name     string
+		input    []*jira.IssueLink
+		expected []IssueLink
+	}{
+		{
+			name:     "no links",
+			input:    nil,
+			expected: nil,
+		},
+		{
+			name: "outward and inward links",
+			input: []*jira.IssueLink{
+				{Type: blocks, OutwardIssue: &jira.Issue{Key: "EV-2"}},
+				{Type: blocks, InwardIssue: &jira.Issue{Key: "EV-3"}},
+				{Type: relates, InwardIssue: &jira.Issue{Key: "OPS-4"}},
+			},
+			expected: []IssueLink{
+				{Type: "Blocks", Direction: LinkOutward, Relation: "blocks", Key: "EV-2"},
+				{Type: "Blocks", Direction: LinkInward, Relation: "is blocked by", Key: "EV-3"},
+				{Type: "Relates", Direction: LinkInward, Relation: "relates to", Key: "OPS-4"},
+			},
+		},
+		{
+			name: "links without a linked issue are skipped",
+			input: []*jira.IssueLink{
+				nil,
+				{Type: blocks},
+				{Type: blocks, OutwardIssue: &jira.Issue{Key: "EV-2"}},
+			},
+			expected: []IssueLink{
+				{Type: "Blocks", Direction: LinkOutward, Relation: "blocks", Key: "EV-2"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getIssueLinks(tt.input))
+		})
+	}
+}
+
+// Test getHTTPStatusCode function
+func TestGetHTTPStatusCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		resp     *jira.Response
+		expected int
+	}{
+		{
+			name:     "nil response (network failure)",
+			resp:     nil,
+			expected: 0,
+		},
+		{
+			name:     "response without HTTP response",
+			resp:     &jira.Response{},
+			expected: 0,
+		},
+		{
+			name:     "not found",
+			resp:     &jira.Response{Response: &http.Response{StatusCode: http.StatusNotFound}},
+			expected: 404,
+		},
+		{
+			name:     "forbidden",
+			resp:     &jira.Response{Response: &http.Response{StatusCode: http.StatusForbidden}},
+			expected: 403,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getHTTPStatusCode(tt.resp))
+		})
+	}
+}
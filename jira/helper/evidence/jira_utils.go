@@ -0,0 +1,302 @@
+package evidence
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	jira "github.com/andygrunwald/go-jira/v2/cloud"
+)
+
+// Field extractors for JIRA objects - all handle nil values safely
+
+func getStatusName(status *jira.Status) string {
+	if status == nil {
+		return ""
+	}
+	return status.Name
+}
+
+// getStatusCategory returns the name of the status's category (To Do, In Progress or Done), or ""
+// when the status has none (JIRA reports those with the key "undefined")
+func getStatusCategory(status *jira.Status) string {
+	if status == nil || status.StatusCategory.Key == "undefined" {
+		return ""
+	}
+	return status.StatusCategory.Name
+}
+
+// getIssueTypeName returns the issue type name; a response without issuetype yields the zero IssueType
+func getIssueTypeName(issueType jira.IssueType) string {
+	return issueType.Name
+}
+
+// getProjectKey returns the project key; a response without project yields the zero Project
+func getProjectKey(project jira.Project) string {
+	return project.Key
+}
+
+func getReporterName(reporter *jira.User) string {
+	if reporter == nil {
+		return ""
+	}
+	return reporter.DisplayName
+}
+
+func getPriorityName(priority *jira.Priority) string {
+	if priority == nil {
+		return ""
+	}
+	return priority.Name
+}
+
+func getResolutionName(resolution *jira.Resolution) string {
+	if resolution == nil {
+		return ""
+	}
+	return resolution.Name
+}
+
+// getSecurityLevel returns the name of the issue security level. go-jira has no field for it,
+// so it is read from the unknown fields; "" when the ticket has no security level.
+func getSecurityLevel(fields map[string]interface{}) string {
+	security, ok := fields["security"].(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	name, _ := security["name"].(string)
+	return name
+}
+
+func getAssignee(assignee *jira.User) *string {
+	if assignee == nil {
+		return nil
+	}
+	return &assignee.DisplayName
+}
+
+// getUserID returns the user's email address, or the accountId when JIRA Cloud hides the email
+func getUserID(user *jira.User) string {
+	if user == nil {
+		return ""
+	}
+	return getOrDefault(user.EmailAddress, user.AccountID)
+}
+
+// getHTTPStatusCode returns the HTTP status code of a JIRA response, or 0 if there was no response
+func getHTTPStatusCode(resp *jira.Response) int {
+	if resp == nil || resp.Response == nil {
+		return 0
+	}
+	return resp.StatusCode
+}
+
+// getIssueLinks converts JIRA issue links into IssueLinks. A link carries either an outward issue
+// (this ticket is the source, e.g. "blocks") or an inward issue (this ticket is the target,
+// e.g. "is blocked by"); links without a linked issue are skipped.
+func getIssueLinks(issueLinks []*jira.IssueLink) []IssueLink {
+	var links []IssueLink
+	for _, link := range issueLinks {
+		if link == nil {
+			continue
+		}
+		switch {
+		case link.OutwardIssue != nil:
+			links = append(links, IssueLink{Type: link.Type.Name, Direction: LinkOutward, Relation: link.Type.Outward, Key: link.OutwardIssue.Key})
+		case link.InwardIssue != nil:
+			links = append(links, IssueLink{Type: link.Type.Name, Direction: LinkInward, Relation: link.Type.Inward, Key: link.InwardIssue.Key})
+		}
+	}
+	return links
+}
+
+// sprintNamePattern matches the name component of JIRA's serialized sprint representation, e.g.
+// com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=1,rapidViewId=2,state=CLOSED,name=Sprint 1,...]
+var sprintNamePattern = regexp.MustCompile(`[\[,]name=([^,\]]*)`)
+
+// getSprintNames extracts sprint names from the agile sprint custom field.
+// The sprint field ID differs between instances, so every custom field is inspected
+// and values that look like sprints (serialized strings or sprint objects) are collected.
+func getSprintNames(customFields map[string]interface{}) []string {
+	if len(customFields) == 0 {
+		return nil
+	}
+
+	// Iterate in a stable order so the output is deterministic
+	keys := make([]string, 0, len(customFields))
+	for key := range customFields {
+		if strings.HasPrefix(key, "customfield_") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	var sprints []string
+	for _, key := range keys {
+		values, ok := customFields[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, value := range values {
+			if name := parseSprintName(value); name != "" {
+				sprints = append(sprints, name)
+			}
+		}
+	}
+
+	return sprints
+}
+
+// parseSprintName returns the sprint name from a single sprint value, or "" if it isn't a sprint
+func parseSprintName(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		// Server/Data Center return sprints as serialized strings
+		if !strings.Contains(v, "sprint.Sprint@") {
+			return ""
+		}
+		if match := sprintNamePattern.FindStringSubmatch(v); match != nil {
+			return match[1]
+		}
+	case map[string]interface{}:
+		// Cloud returns sprints as objects carrying a board ID and state
+		if _, hasState := v["state"]; !hasState {
+			return ""
+		}
+		if _, hasBoard := v["boardId"]; !hasBoard {
+			return ""
+		}
+		name, _ := v["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// getTimeAsString converts various time representations to string format
+func getTimeAsString(timeField interface{}) string {
+	if timeField == nil {
+		return ""
+	}
+
+	// Zero times come from fields missing in the response, which would otherwise
+	// be rendered as 0001-01-01
+	switch v := timeField.(type) {
+	case string:
+		return v
+	case time.Time:
+		return formatJiraTime(v)
+	case *time.Time:
+		if v != nil {
+			return formatJiraTime(*v)
+		}
+		return ""
+	case jira.Time:
+		return formatJiraTime(time.Time(v))
+	case *jira.Time:
+		if v != nil {
+			return formatJiraTime(time.Time(*v))
+		}
+		return ""
+	default:
+		// Try JSON marshaling as last resort
+		if jsonBytes, err := json.Marshal(timeField); err == nil {
+			var timeStr string
+			if json.Unmarshal(jsonBytes, &timeStr) == nil && timeStr != "" {
+				return timeStr
+			}
+		}
+		// Final fallback
+		return fmt.Sprintf("%v", timeField)
+	}
+}
+
+// formatJiraTime formats a time in JIRA's format, or returns "" for the zero time
+func formatJiraTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(JiraTimeFormat)
+}
+
+// getDescription extracts description text from JIRA description field
+func getDescription(desc interface{}) string {
+	if desc == nil {
+		return ""
+	}
+
+	// Handle the Atlassian Document Format (ADF) structure
+	descMap, ok := desc.(map[string]interface{})
+	if !ok {
+		// Fallback to string representation
+		return strings.TrimSpace(fmt.Sprintf("%v", desc))
+	}
+
+	content, ok := descMap["content"].([]interface{})
+	if !ok {
+		return fmt.Sprintf("%v", desc)
+	}
+
+	// Block-level nodes (paragraphs) are separated by a blank line, like paragraphs in markdown
+	var blocks []string
+	for _, item := range content {
+		if text := strings.TrimSpace(extractTextFromADFNode(item)); text != "" {
+			blocks = append(blocks, text)
+		}
+	}
+
+	if len(blocks) == 0 {
+		return fmt.Sprintf("%v", desc)
+	}
+	return strings.Join(blocks, "\n\n")
+}
+
+// truncateDescription cuts a description to its first max characters followed by an ellipsis,
+// so a wall-of-text ticket doesn't swamp the report. A max of 0 disables truncation.
+func truncateDescription(desc string, max int) string {
+	if max <= 0 || utf8.RuneCountInString(desc) <= max {
+		return desc
+	}
+	runes := []rune(desc)
+	return strings.TrimRightFunc(string(runes[:max]), unicode.IsSpace) + "…"
+}
+
+// extractTextFromADFNode extracts text from an ADF node (paragraph, text, etc.)
+func extractTextFromADFNode(node interface{}) string {
+	nodeMap, ok := node.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+
+	nodeType, _ := nodeMap["type"].(string)
+
+	switch nodeType {
+	case "paragraph":
+		// Extract text from paragraph's content
+		content, ok := nodeMap["content"].([]interface{})
+		if !ok {
+			return ""
+		}
+
+		var texts []string
+		for _, item := range content {
+			if text := extractTextFromADFNode(item); text != "" {
+				texts = append(texts, text)
+			}
+		}
+		return strings.Join(texts, "")
+
+	case "text":
+		// Direct text node
+		text, _ := nodeMap["text"].(string)
+		return text
+
+	default:
+		// Handle other node types if needed
+		return ""
+	}
+}
@@ -0,0 +1,58 @@
+package evidence
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Placeholders of a link template (--link-template)
+const (
+	LinkPlaceholderBase    = "{base}"
+	LinkPlaceholderKey     = "{key}"
+	LinkPlaceholderProject = "{project}"
+)
+
+// DefaultLinkTemplate is the browse link of a ticket, e.g. https://example.atlassian.net/browse/EV-1
+const DefaultLinkTemplate = "{base}/browse/{key}"
+
+// linkStyles maps the --link-style presets to link templates; the tab styles open the issue on
+// its history or comments tab
+var linkStyles = map[string]string{
+	"browse":   DefaultLinkTemplate,
+	"history":  "{base}/browse/{key}?page=com.atlassian.jira.plugin.system.issuetabpanels:changehistory-tabpanel",
+	"comments": "{base}/browse/{key}?page=com.atlassian.jira.plugin.system.issuetabpanels:comment-tabpanel",
+}
+
+// ResolveLinkTemplate turns a --link-style preset or a --link-template into the template of the
+// ticket links. An explicit template wins over the style; both empty is DefaultLinkTemplate.
+func ResolveLinkTemplate(style, template string) (string, error) {
+	if template != "" {
+		if !strings.Contains(template, LinkPlaceholderKey) {
+			return "", &ValidationError{Field: "link-template", Value: template, Err: fmt.Errorf("must contain %s", LinkPlaceholderKey)}
+		}
+		return template, nil
+	}
+	if style == "" {
+		return DefaultLinkTemplate, nil
+	}
+	if resolved, ok := linkStyles[strings.ToLower(style)]; ok {
+		return resolved, nil
+	}
+
+	styles := make([]string, 0, len(linkStyles))
+	for name := range linkStyles {
+		styles = append(styles, name)
+	}
+	sort.Strings(styles)
+	return "", &ValidationError{Field: "link-style", Value: style, Err: fmt.Errorf("must be one of: %s", strings.Join(styles, ", "))}
+}
+
+// issueLink fills a link template for the issue; an empty template is DefaultLinkTemplate
+func issueLink(template, baseURL, key, project string) string {
+	return strings.NewReplacer(
+		LinkPlaceholderBase, baseURL,
+		LinkPlaceholderKey, key,
+		LinkPlaceholderProject, project,
+	).Replace(getOrDefault(template, DefaultLinkTemplate))
+}
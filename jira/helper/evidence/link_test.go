@@ -0,0 +1,57 @@
+package evidence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveLinkTemplate(t *testing.T) {
+	tests := []struct {
+		name        string
+		style       string
+		template    string
+		expected    string
+		expectError string
+	}{
+		{name: "Default", expected: DefaultLinkTemplate},
+		{name: "Browse style", style: "browse", expected: DefaultLinkTemplate},
+		{name: "History style", style: "History", expected: "{base}/browse/{key}?page=com.atlassian.jira.plugin.system.issuetabpanels:changehistory-tabpanel"},
+		{name: "Template wins over style", style: "history", template: "{base}/jira/browse/{key}", expected: "{base}/jira/browse/{key}"},
+		{name: "Template without key", template: "{base}/browse/", expectError: "link-template"},
+		{name: "Unknown style", style: "board", expectError: "must be one of: browse, comments, history"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template, err := ResolveLinkTemplate(tt.style, tt.template)
+			if tt.expectError != "" {
+				var validationErr *ValidationError
+				require.ErrorAs(t, err, &validationErr)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, template)
+		})
+	}
+}
+
+func TestIssueLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"Default template", "", "https://jira.example.com/browse/EV-1"},
+		{"Custom scheme", "{base}/projects/{project}/issues/{key}", "https://jira.example.com/projects/EV/issues/EV-1"},
+		{"Absolute URL without base", "https://tickets.example.com/{key}", "https://tickets.example.com/EV-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, issueLink(tt.template, "https://jira.example.com", "EV-1", "EV"))
+		})
+	}
+}
@@ -0,0 +1,610 @@
+package evidence
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// DefaultDateFormat is the layout used for dates in the markdown report
+const DefaultDateFormat = "2006-01-02 15:04:05"
+
+// dateFormatPresets maps the named --date-format presets to Go time layouts
+var dateFormatPresets = map[string]string{
+	"iso":     "2006-01-02T15:04:05-07:00",
+	"date":    "2006-01-02",
+	"rfc3339": time.RFC3339,
+}
+
+// Task orders for the markdown report (--sort-by)
+const (
+	SortByKey      = "key"
+	SortByStatus   = "status"
+	SortByPriority = "priority"
+	SortByCreated  = "created"
+)
+
+// GroupByProject sections the task details of the markdown report by project (--group-by)
+const GroupByProject = "project"
+
+// unknownProjectGroup heads the tasks without a project, such as failed fetches
+const unknownProjectGroup = "Unknown"
+
+// priorityRanks orders the default priorities of JIRA Cloud (Highest..Lowest) and Server
+// (Blocker..Trivial); other priorities sort after them
+var priorityRanks = map[string]int{
+	"highest": 0, "blocker": 0,
+	"high": 1, "critical": 1,
+	"medium": 2, "major": 2,
+	"low": 3, "minor": 3,
+	"lowest": 4, "trivial": 4,
+}
+
+// MarkdownOptions holds optional settings for the markdown report
+type MarkdownOptions struct {
+	// DateFormat is the Go time layout for dates (default: DefaultDateFormat)
+	DateFormat string
+	// Location converts dates into this timezone before formatting (default: keep the JIRA offset)
+	Location *time.Location
+	// Anonymize replaces people's names with "User A", "User B", ... (see Anonymize)
+	Anonymize bool
+	// SortBy orders the tasks by key, status, priority or created (default: extraction order)
+	SortBy string
+	// TOC adds a table of contents linking each ticket to its section in the task details
+	TOC bool
+	// GroupBy sections the task details under a heading per project (default: one flat list)
+	GroupBy string
+}
+
+// ResolveDateFormat turns a --date-format value (preset name or Go layout) into a Go layout
+func ResolveDateFormat(value string) (string, error) {
+	if value == "" {
+		return DefaultDateFormat, nil
+	}
+	if layout, ok := dateFormatPresets[strings.ToLower(value)]; ok {
+		return layout, nil
+	}
+
+	// A layout without any reference-time element formats any time to itself
+	if time.Date(1999, 12, 31, 23, 58, 59, 0, time.UTC).Format(value) == value {
+		return "", &ValidationError{Field: "date-format", Value: value, Err: fmt.Errorf("must be a Go time layout or one of: iso, date, rfc3339")}
+	}
+	return value, nil
+}
+
+// ResolveSortBy validates a --sort-by value and returns it in its canonical (lower case) form
+func ResolveSortBy(value string) (string, error) {
+	switch sortBy := strings.ToLower(value); sortBy {
+	case "", SortByKey, SortByStatus, SortByPriority, SortByCreated:
+		return sortBy, nil
+	}
+	return "", &ValidationError{Field: "sort-by", Value: value, Err: fmt.Errorf("must be one of: %s, %s, %s, %s", SortByKey, SortByStatus, SortByPriority, SortByCreated)}
+}
+
+// ResolveGroupBy validates a --group-by value and returns it in its canonical (lower case) form
+func ResolveGroupBy(value string) (string, error) {
+	switch groupBy := strings.ToLower(value); groupBy {
+	case "", GroupByProject:
+		return groupBy, nil
+	}
+	return "", &ValidationError{Field: "group-by", Value: value, Err: fmt.Errorf("must be %s", GroupByProject)}
+}
+
+// groupTasks orders the tasks by project key for --group-by project, with the tasks without a
+// project last. Within a project the tasks keep their (sorted) order.
+func groupTasks(tasks []JiraTransitionResult, groupBy string) []JiraTransitionResult {
+	if groupBy != GroupByProject {
+		return tasks
+	}
+
+	grouped := append([]JiraTransitionResult{}, tasks...)
+	sort.SliceStable(grouped, func(i, j int) bool {
+		a, b := grouped[i].Project, grouped[j].Project
+		if a == "" || b == "" {
+			return b == "" && a != ""
+		}
+		return a < b
+	})
+	return grouped
+}
+
+// projectGroup returns the heading a task is grouped under by --group-by project
+func projectGroup(task JiraTransitionResult) string {
+	return getOrDefault(task.Project, unknownProjectGroup)
+}
+
+// sortTasks returns the tasks in the requested order. Tasks with a blank or unknown value for the
+// sort field come last, and ties keep their extraction order.
+func sortTasks(tasks []JiraTransitionResult, sortBy string) []JiraTransitionResult {
+	if sortBy == "" {
+		return tasks
+	}
+
+	sorted := append([]JiraTransitionResult{}, tasks...)
+	var less func(a, b JiraTransitionResult) bool
+	switch sortBy {
+	case SortByKey:
+		less = func(a, b JiraTransitionResult) bool { return compareKeys(a.Key, b.Key) < 0 }
+	case SortByStatus:
+		less = func(a, b JiraTransitionResult) bool {
+			if a.Status == "" || b.Status == "" {
+				return b.Status == "" && a.Status != ""
+			}
+			return strings.ToLower(a.Status) < strings.ToLower(b.Status)
+		}
+	case SortByPriority:
+		less = func(a, b JiraTransitionResult) bool { return priorityRank(a.Priority) < priorityRank(b.Priority) }
+	case SortByCreated:
+		less = func(a, b JiraTransitionResult) bool {
+			aTime, aErr := parseJiraTime(a.Created)
+			bTime, bErr := parseJiraTime(b.Created)
+			if aErr != nil || bErr != nil {
+				return bErr != nil && aErr == nil
+			}
+			return aTime.Before(bTime)
+		}
+	default:
+		return tasks
+	}
+
+	sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+	return sorted
+}
+
+// priorityRank ranks a priority name for sorting: known priorities first, then other
+// non-blank priorities, then blank ones
+func priorityRank(priority string) int {
+	if priority == "" {
+		return len(priorityRanks) + 1
+	}
+	if rank, ok := priorityRanks[strings.ToLower(priority)]; ok {
+		return rank
+	}
+	return len(priorityRanks)
+}
+
+// compareKeys compares JIRA keys by project and then numerically, so EV-2 comes before EV-10
+func compareKeys(a, b string) int {
+	aProject, aNumber, _ := strings.Cut(a, "-")
+	bProject, bNumber, _ := strings.Cut(b, "-")
+	if aProject != bProject {
+		return strings.Compare(aProject, bProject)
+	}
+	aValue, aErr := strconv.Atoi(aNumber)
+	bValue, bErr := strconv.Atoi(bNumber)
+	if aErr != nil || bErr != nil || aValue == bValue {
+		return strings.Compare(aNumber, bNumber)
+	}
+	if aValue < bValue {
+		return -1
+	}
+	return 1
+}
+
+// formatTime formats a time using the configured layout
+func (o MarkdownOptions) formatTime(t time.Time) string {
+	if o.Location != nil {
+		t = t.In(o.Location)
+	}
+	return t.Format(getOrDefault(o.DateFormat, DefaultDateFormat))
+}
+
+// GenerateMarkdownFromJSON reads a JSON file and generates markdown
+func GenerateMarkdownFromJSON(inputFile string, outputFile string, options MarkdownOptions) error {
+	// Read and parse JSON file
+	response, err := LoadResponseFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	// Generate markdown
+	markdown := generateMarkdown(response, options)
+
+	// Write markdown to file
+	err = writeFile(outputFile, []byte(markdown))
+	if err != nil {
+		return fmt.Errorf("error writing markdown file: %v", err)
+	}
+
+	fmt.Printf("Markdown file generated: %s\n", outputFile)
+	return nil
+}
+
+// SplitIndexFile is the file that GenerateSplitMarkdown writes next to the per-ticket files
+const SplitIndexFile = "index.md"
+
+// GenerateSplitMarkdownFromJSON reads a JSON file and writes one markdown file per ticket into
+// outputDir, named after the ticket key, plus an index.md linking them (see GenerateSplitMarkdown)
+func GenerateSplitMarkdownFromJSON(inputFile string, outputDir string, options MarkdownOptions) error {
+	response, err := LoadResponseFile(inputFile)
+	if err != nil {
+		return err
+	}
+
+	if err := GenerateSplitMarkdown(response, outputDir, options); err != nil {
+		return err
+	}
+
+	fmt.Printf("Markdown files generated: %d tickets and %s in %s\n", len(response.Tasks), SplitIndexFile, outputDir)
+	return nil
+}
+
+// GenerateSplitMarkdown writes each task's details to outputDir/<KEY>.md, using the same
+// rendering as the task details of the single-file report, and an index.md with the summary
+// table linking them. Characters that aren't safe in file names are replaced in the key.
+func GenerateSplitMarkdown(response TransitionCheckResponse, outputDir string, options MarkdownOptions) error {
+	if options.Anonymize {
+		response = Anonymize(response)
+	}
+	response.Tasks = sortTasks(response.Tasks, options.SortBy)
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("error creating markdown directory: %v", err)
+	}
+
+	var index strings.Builder
+	index.WriteString("# JIRA Tasks Report\n\n")
+	now := time.Now()
+	index.WriteString(fmt.Sprintf("Generated on: %s\n\n", options.formatTime(now)))
+	index.WriteString(fmt.Sprintf("Total tasks: %d\n\n", len(response.Tasks)))
+	index.WriteString("| Key | Status | Type | Priority | Assignee | Transitions | Age |\n")
+	index.WriteString("|-----|--------|------|----------|----------|-------------|-----|\n")
+
+	used := make(map[string]bool, len(response.Tasks))
+	for _, task := range response.Tasks {
+		fileName := ticketFileName(task.Key, used)
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("# %s\n\n", markdownKey(task)))
+		writeMarkdownTask(&sb, task, options)
+		if err := writeFile(filepath.Join(outputDir, fileName), []byte(sb.String())); err != nil {
+			return fmt.Errorf("error writing markdown file: %v", err)
+		}
+
+		assignee := "Unassigned"
+		if task.Assignee != nil && *task.Assignee != "" {
+			assignee = *task.Assignee
+		}
+		index.WriteString(fmt.Sprintf("| [%s](%s) | %s | %s | %s | %s | %d | %s |\n",
+			task.Key, fileName, task.Status, task.Type, task.Priority, assignee, len(task.Transitions), ticketAge(task, now)))
+	}
+
+	if err := writeFile(filepath.Join(outputDir, SplitIndexFile), []byte(index.String())); err != nil {
+		return fmt.Errorf("error writing markdown index: %v", err)
+	}
+	return nil
+}
+
+// ticketFileName returns the markdown file name for a ticket key: characters other than letters,
+// digits, '-' and '_' become '_' (e.g. OPS#7 is OPS_7.md). A name already in used gets a numeric
+// suffix, so keys that sanitize alike don't overwrite each other; the index file name is reserved.
+func ticketFileName(key string, used map[string]bool) string {
+	base := sanitizeFileName(key)
+	name := base + ".md"
+	for i := 2; used[name] || name == SplitIndexFile; i++ {
+		name = fmt.Sprintf("%s-%d.md", base, i)
+	}
+	used[name] = true
+	return name
+}
+
+// sanitizeFileName turns a ticket key into a file name without extension: characters other
+// than letters, digits, '-' and '_' become '_'
+func sanitizeFileName(key string) string {
+	name := strings.Map(func(r rune) rune {
+		if r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_') {
+			return r
+		}
+		return '_'
+	}, key)
+	if name == "" {
+		return "_"
+	}
+	return name
+}
+
+// generateMarkdown creates markdown content from JIRA data
+func generateMarkdown(response TransitionCheckResponse, options MarkdownOptions) string {
+	if options.Anonymize {
+		response = Anonymize(response)
+	}
+	response.Tasks = groupTasks(sortTasks(response.Tasks, options.SortBy), options.GroupBy)
+
+	var sb strings.Builder
+
+	// Header
+	sb.WriteString("# JIRA Tasks Report\n\n")
+	now := time.Now()
+	sb.WriteString(fmt.Sprintf("Generated on: %s\n\n", options.formatTime(now)))
+	sb.WriteString(fmt.Sprintf("Total tasks: %d\n\n", len(response.Tasks)))
+
+	var errorTasks []JiraTransitionResult
+	totalTransitions := 0
+	for _, task := range response.Tasks {
+		if task.Status == ErrorStatus {
+			errorTasks = append(errorTasks, task)
+		}
+		totalTransitions += len(task.Transitions)
+	}
+	sb.WriteString(fmt.Sprintf("Total transitions: %d\n\n", totalTransitions))
+	if len(errorTasks) > 0 {
+		sb.WriteString(fmt.Sprintf("Tasks with errors: %d\n\n", len(errorTasks)))
+	}
+
+	if options.TOC {
+		sb.WriteString("## Contents\n\n")
+		for i, task := range response.Tasks {
+			sb.WriteString(fmt.Sprintf("- [%s](#%s)\n", task.Key, headingAnchor(fmt.Sprintf("%d. %s", i+1, task.Key))))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Summary table
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString("| Key | Status | Type | Priority | Assignee | Transitions | Age |\n")
+	sb.WriteString("|-----|--------|------|----------|----------|-------------|-----|\n")
+
+	for _, task := range response.Tasks {
+		assignee := "Unassigned"
+		if task.Assignee != nil && *task.Assignee != "" {
+			assignee = *task.Assignee
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %d | %s |\n",
+			markdownKey(task), task.Status, task.Type, task.Priority, assignee, len(task.Transitions), ticketAge(task, now)))
+	}
+	sb.WriteString("\n")
+
+	// Errors get their own list so reviewers can see what couldn't be fetched at a glance
+	if len(errorTasks) > 0 {
+		sb.WriteString("## Errors\n\n")
+		sb.WriteString("| Key | Error |\n")
+		sb.WriteString("|-----|-------|\n")
+		for _, task := range errorTasks {
+			sb.WriteString(fmt.Sprintf("| %s | %s |\n", task.Key, escapeTableCell(task.Description)))
+		}
+		sb.WriteString("\n")
+	}
+
+	// Detailed task information, under one heading per project when grouped
+	if options.GroupBy != GroupByProject {
+		sb.WriteString("## Task Details\n\n")
+	}
+
+	for i, task := range response.Tasks {
+		if options.GroupBy == GroupByProject && (i == 0 || projectGroup(task) != projectGroup(response.Tasks[i-1])) {
+			sb.WriteString(fmt.Sprintf("## Project: %s\n\n", projectGroup(task)))
+		}
+		sb.WriteString(fmt.Sprintf("### %d. %s\n\n", i+1, markdownKey(task)))
+		writeMarkdownTask(&sb, task, options)
+		sb.WriteString("\n---\n\n")
+	}
+
+	// Status distribution
+	statusCount := make(map[string]int)
+	for _, task := range response.Tasks {
+		statusCount[task.Status]++
+	}
+
+	sb.WriteString("## Status Distribution\n\n")
+	sb.WriteString("| Status | Count |\n")
+	sb.WriteString("|--------|-------|\n")
+	for status, count := range statusCount {
+		sb.WriteString(fmt.Sprintf("| %s | %d |\n", status, count))
+	}
+
+	writeCategoryDistribution(&sb, response.Tasks)
+
+	return sb.String()
+}
+
+// statusCategoryOrder is the order of JIRA's status categories in the category distribution
+var statusCategoryOrder = []string{"To Do", "In Progress", "Done"}
+
+// writeCategoryDistribution writes how many tasks are in each status category, which groups the
+// many custom status names of different teams into JIRA's buckets. Tasks without a category
+// (failed fetches, files from older versions) are counted as Unknown; the section is left out when
+// no task has one.
+func writeCategoryDistribution(sb *strings.Builder, tasks []JiraTransitionResult) {
+	categoryCount := make(map[string]int)
+	unknown := 0
+	for _, task := range tasks {
+		if task.StatusCategory == "" {
+			unknown++
+			continue
+		}
+		categoryCount[task.StatusCategory]++
+	}
+	if len(categoryCount) == 0 {
+		return
+	}
+
+	// JIRA's own categories first, then any other (e.g. localized) names alphabetically
+	known := make(map[string]bool, len(statusCategoryOrder))
+	for _, category := range statusCategoryOrder {
+		known[category] = true
+	}
+	categories := append([]string{}, statusCategoryOrder...)
+	var others []string
+	for category := range categoryCount {
+		if !known[category] {
+			others = append(others, category)
+		}
+	}
+	sort.Strings(others)
+	categories = append(categories, others...)
+
+	sb.WriteString("\n## Status Category Distribution\n\n")
+	sb.WriteString("| Category | Count |\n")
+	sb.WriteString("|----------|-------|\n")
+	for _, category := range categories {
+		if count := categoryCount[category]; count > 0 {
+			sb.WriteString(fmt.Sprintf("| %s | %d |\n", category, count))
+		}
+	}
+	if unknown > 0 {
+		sb.WriteString(fmt.Sprintf("| Unknown | %d |\n", unknown))
+	}
+}
+
+// markdownKey renders a task's key, linked to the ticket when the JSON has its link
+func markdownKey(task JiraTransitionResult) string {
+	if task.Link != "" {
+		return fmt.Sprintf("[%s](%s)", task.Key, task.Link)
+	}
+	return task.Key
+}
+
+// writeMarkdownTask writes the details of one task below its heading: basic information,
+// people, dates, linked issues, description and transition history
+func writeMarkdownTask(sb *strings.Builder, task JiraTransitionResult, options MarkdownOptions) {
+	// Basic information
+	sb.WriteString("**Basic Information:**\n")
+	sb.WriteString(fmt.Sprintf("- **Status:** %s\n", task.Status))
+	sb.WriteString(fmt.Sprintf("- **Type:** %s\n", task.Type))
+	sb.WriteString(fmt.Sprintf("- **Project:** %s\n", task.Project))
+	sb.WriteString(fmt.Sprintf("- **Priority:** %s\n", task.Priority))
+	if task.Status != ErrorStatus {
+		sb.WriteString(fmt.Sprintf("- **Resolution:** %s\n", getOrDefault(task.Resolution, "Unresolved")))
+		sb.WriteString(fmt.Sprintf("- **Security Level:** %s\n", task.SecurityLevel))
+	}
+	if task.ReferencedAs != "" {
+		sb.WriteString(fmt.Sprintf("- **Referenced As:** %s\n", task.ReferencedAs))
+	}
+	if task.RequestedKey != "" {
+		sb.WriteString(fmt.Sprintf("- **Requested As:** %s (moved)\n", task.RequestedKey))
+	}
+	if len(task.Sprints) > 0 {
+		sb.WriteString(fmt.Sprintf("- **Sprints:** %s\n", strings.Join(task.Sprints, ", ")))
+	}
+
+	// People
+	sb.WriteString("\n**People:**\n")
+	assignee := "Unassigned"
+	if task.Assignee != nil && *task.Assignee != "" {
+		assignee = *task.Assignee
+	}
+	sb.WriteString(fmt.Sprintf("- **Assignee:** %s\n", assignee))
+	sb.WriteString(fmt.Sprintf("- **Reporter:** %s\n", task.Reporter))
+
+	// Dates
+	sb.WriteString("\n**Dates:**\n")
+	sb.WriteString(fmt.Sprintf("- **Created:** %s\n", formatDate(task.Created, options)))
+	sb.WriteString(fmt.Sprintf("- **Updated:** %s\n", formatDate(task.Updated, options)))
+	if task.ResolutionDate != "" {
+		sb.WriteString(fmt.Sprintf("- **Resolved:** %s\n", formatDate(task.ResolutionDate, options)))
+	}
+
+	// Linked issues
+	if len(task.Links) > 0 {
+		sb.WriteString("\n**Linked Issues:**\n")
+		for _, link := range task.Links {
+			relation := getOrDefault(link.Relation, link.Type)
+			sb.WriteString(fmt.Sprintf("- %s %s\n", relation, link.Key))
+		}
+	}
+
+	// Description
+	if task.Description != "" {
+		sb.WriteString("\n**Description:**\n")
+		sb.WriteString(fmt.Sprintf("> %s\n", strings.ReplaceAll(task.Description, "\n", "\n> ")))
+	}
+
+	// Transitions
+	if len(task.Transitions) > 0 {
+		sb.WriteString("\n**Transition History:**\n\n")
+		sb.WriteString("| From Status | To Status | Author | Date |\n")
+		sb.WriteString("|-------------|-----------|--------|------|\n")
+
+		for _, transition := range task.Transitions {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+				transition.FromStatus,
+				transition.ToStatus,
+				transition.Author,
+				formatDate(transition.TransitionTime, options)))
+		}
+	}
+}
+
+// headingAnchor returns the anchor GitHub generates for a heading with this text: lower case,
+// spaces become hyphens and punctuation other than hyphens and underscores is dropped.
+// A linked key contributes only its link text, so "### 1. [EV-1](url)" is #1-ev-1.
+func headingAnchor(text string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(text) {
+		switch {
+		case r == ' ':
+			sb.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+// escapeTableCell makes a value safe to place in a single markdown table cell
+func escapeTableCell(value string) string {
+	value = strings.ReplaceAll(value, "|", "\\|")
+	return strings.ReplaceAll(value, "\n", " ")
+}
+
+// parseJiraTime parses a timestamp as written by JIRA (JiraTimeFormat)
+func parseJiraTime(value string) (time.Time, error) {
+	return time.Parse(JiraTimeFormat, value)
+}
+
+// ticketAge is how long the ticket has been open, from its creation until it was resolved or,
+// while unresolved, until now. It is an ISO 8601 duration in days and hours (e.g. P3DT4H), or
+// "N/A" when a date is missing or can't be parsed.
+func ticketAge(task JiraTransitionResult, now time.Time) string {
+	created, err := parseJiraTime(task.Created)
+	if err != nil {
+		return "N/A"
+	}
+
+	end := now
+	if task.ResolutionDate != "" {
+		if end, err = parseJiraTime(task.ResolutionDate); err != nil {
+			return "N/A"
+		}
+	}
+	return formatAge(end.Sub(created))
+}
+
+// formatAge formats a duration as an ISO 8601 duration in whole days and hours
+func formatAge(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	hours := int64(d / time.Hour)
+	days, hours := hours/24, hours%24
+	switch {
+	case days == 0:
+		return fmt.Sprintf("PT%dH", hours)
+	case hours == 0:
+		return fmt.Sprintf("P%dD", days)
+	default:
+		return fmt.Sprintf("P%dDT%dH", days, hours)
+	}
+}
+
+// formatDate formats a JIRA date string to a more readable format
+func formatDate(dateStr string, options MarkdownOptions) string {
+	if dateStr == "" {
+		return "N/A"
+	}
+
+	// Try to parse the JIRA date format
+	t, err := parseJiraTime(dateStr)
+	if err != nil {
+		// If parsing fails, return the original string
+		return dateStr
+	}
+
+	// Return in a more readable format
+	return options.formatTime(t)
+}
@@ -0,0 +1,935 @@
+package evidence
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMarkdownFromJSON(t *testing.T) {
+	// Create temp directory for test files
+	tempDir, err := os.MkdirTemp("", "markdown-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	tests := []struct {
+		name        string
+		jsonContent string
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name: "Valid JSON with tasks",
+			jsonContent: `{
+				"tasks": [
+					{
+						"key": "TEST-123",
+						"status": "Done",
+						"description": "Test task",
+						"type": "Task",
+						"project": "TEST",
+						"created": "2025-01-01T10:00:00.000+0300",
+						"updated": "2025-01-02T15:00:00.000+0300",
+						"assignee": "John Doe",
+						"reporter": "Jane Smith",
+						"priority": "High",
+						"transitions": []
+					}
+				]
+			}`,
+			expectError: false,
+		},
+		{
+			name:        "Invalid JSON",
+			jsonContent: `{invalid json}`,
+			expectError: true,
+			errorMsg:    "error parsing JSON",
+		},
+		{
+			name:        "Empty file",
+			jsonContent: "",
+			expectError: true,
+			errorMsg:    "error parsing JSON",
+		},
+		{
+			name: "Valid JSON with empty tasks",
+			jsonContent: `{
+				"tasks": []
+			}`,
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create input JSON file
+			inputFile := filepath.Join(tempDir, "input.json")
+			outputFile := filepath.Join(tempDir, "output.md")
+
+			err := os.WriteFile(inputFile, []byte(tt.jsonContent), 0644)
+			require.NoError(t, err)
+
+			// Test GenerateMarkdownFromJSON
+			err = GenerateMarkdownFromJSON(inputFile, outputFile, MarkdownOptions{})
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+				// Verify output file exists
+				_, err = os.Stat(outputFile)
+				assert.NoError(t, err)
+			}
+
+			// Clean up
+			os.Remove(inputFile)
+			os.Remove(outputFile)
+		})
+	}
+
+	// Test with non-existent file
+	t.Run("Non-existent input file", func(t *testing.T) {
+		err := GenerateMarkdownFromJSON("/non/existent/file.json", "/tmp/output.md", MarkdownOptions{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error reading JSON file")
+	})
+
+	// Test with invalid output path
+	t.Run("Invalid output path", func(t *testing.T) {
+		inputFile := filepath.Join(tempDir, "valid.json")
+		err := os.WriteFile(inputFile, []byte(`{"tasks": []}`), 0644)
+		require.NoError(t, err)
+
+		err = GenerateMarkdownFromJSON(inputFile, "/root/invalid/path/output.md", MarkdownOptions{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error writing markdown file")
+	})
+}
+
+func TestGenerateMarkdown(t *testing.T) {
+	// Test various task scenarios
+	tests := []struct {
+		name     string
+		response TransitionCheckResponse
+		checks   []string // Strings that should appear in the output
+	}{
+		{
+			name: "Single task with transitions and link",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key:         "EV-123",
+						Link:        "https://example.atlassian.net/browse/EV-123",
+						Status:      "Done",
+						Description: "Test description",
+						Type:        "Task",
+						Project:     "EV",
+						Created:     "2025-01-01T10:00:00.000+0300",
+						Updated:     "2025-01-05T15:00:00.000+0300",
+						Assignee:    strPtr("John Doe"),
+						Reporter:    "Jane Smith",
+						Priority:    "High",
+						// Resolved a day and three hours after it was created
+						ResolutionDate: "2025-01-02T13:00:00.000+0300",
+						Transitions: []Transition{
+							{
+								FromStatus:     "To Do",
+								ToStatus:       "In Progress",
+								Author:         "John Doe",
+								AuthorEmail:    "john@example.com",
+								TransitionTime: "2025-01-02T09:00:00.000+0300",
+							},
+						},
+					},
+				},
+			},
+			checks: []string{
+				"# JIRA Tasks Report",
+				"Total tasks: 1",
+				"Total transitions: 1",
+				"| Key | Status | Type | Priority | Assignee | Transitions | Age |",
+				"| [EV-123](https://example.atlassian.net/browse/EV-123) | Done | Task | High | John Doe | 1 | P1DT3H |",
+				"[EV-123](https://example.atlassian.net/browse/EV-123)",
+				"### 1. [EV-123](https://example.atlassian.net/browse/EV-123)",
+				"**Status:** Done",
+				"**Type:** Task",
+				"**Assignee:** John Doe",
+				"**Reporter:** Jane Smith",
+				"**Description:**",
+				"> Test description",
+				"**Transition History:**",
+				"| To Do | In Progress | John Doe | 2025-01-02 09:00:00 |",
+				"## Status Distribution",
+				"| Done | 1 |",
+			},
+		},
+		{
+			name: "Task with linked issues",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key:      "EV-1",
+						Status:   "Done",
+						Type:     "Task",
+						Priority: "Low",
+						Links: []IssueLink{
+							{Type: "Blocks", Direction: LinkOutward, Relation: "blocks", Key: "EV-2"},
+							{Type: "Blocks", Direction: LinkInward, Relation: "is blocked by", Key: "EV-3"},
+							{Type: "Cloners", Direction: LinkOutward, Key: "EV-4"},
+						},
+						Transitions: []Transition{},
+					},
+				},
+			},
+			checks: []string{
+				"**Linked Issues:**\n- blocks EV-2\n- is blocked by EV-3\n- Cloners EV-4\n",
+			},
+		},
+		{
+			name: "Task fetched through a key alias",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key:          "NEW-12",
+						ReferencedAs: "OLD-12",
+						Status:       "Done",
+						Type:         "Task",
+						Project:      "NEW",
+						Priority:     "Low",
+						Transitions:  []Transition{},
+					},
+				},
+			},
+			checks: []string{
+				"### 1. NEW-12",
+				"**Referenced As:** OLD-12",
+			},
+		},
+		{
+			name: "Task with null assignee and no transitions",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key:         "BUG-456",
+						Status:      "Open",
+						Description: "",
+						Type:        "Bug",
+						Project:     "PROJ",
+						Created:     "2025-02-01T12:00:00.000+0300",
+						Updated:     "2025-02-01T12:00:00.000+0300",
+						Assignee:    nil,
+						Reporter:    "Bob Builder",
+						Priority:    "Medium",
+						Transitions: []Transition{},
+					},
+				},
+			},
+			checks: []string{
+				"| BUG-456 | Open | Bug | Medium | Unassigned | 0 |",
+				"**Assignee:** Unassigned",
+				"**Created:** 2025-02-01 12:00:00",
+			},
+		},
+		{
+			name: "Error task",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key:         "ERR-789",
+						Status:      "Error",
+						Description: "Error: Could not retrieve issue",
+						Type:        "Error",
+						Project:     "",
+						Created:     "",
+						Updated:     "",
+						Assignee:    nil,
+						Reporter:    "",
+						Priority:    "",
+						Transitions: []Transition{},
+					},
+				},
+			},
+			checks: []string{
+				"| ERR-789 | Error | Error |  | Unassigned |", // No link for error tasks
+				"### 1. ERR-789", // No link in header for error tasks
+				"**Created:** N/A",
+				"**Updated:** N/A",
+				"> Error: Could not retrieve issue",
+				"Tasks with errors: 1",
+				"## Errors\n\n| Key | Error |\n|-----|-------|\n| ERR-789 | Error: Could not retrieve issue |\n",
+			},
+		},
+		{
+			name: "Mixed successful and error tasks",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{Key: "OK-1", Status: "Done", Type: "Task"},
+					{Key: "ERR-1", Status: ErrorStatus, Type: "Error", Description: "Error 404: issue | not found\nfor key"},
+					{Key: "OK-2", Status: "Open", Type: "Bug"},
+					{Key: "ERR-2", Status: ErrorStatus, Type: "Error", Description: "Error: timeout"},
+				},
+			},
+			checks: []string{
+				"Total tasks: 4",
+				"Tasks with errors: 2",
+				"| ERR-1 | Error 404: issue \\| not found for key |\n| ERR-2 | Error: timeout |\n",
+				"| OK-1 | Done | Task |  | Unassigned |",
+				"| ERR-1 | Error | Error |  | Unassigned |",
+			},
+		},
+		{
+			name: "Multiple tasks with different statuses",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key:      "T1",
+						Status:   "Done",
+						Type:     "Task",
+						Project:  "P1",
+						Created:  "2025-01-01T10:00:00.000+0300",
+						Updated:  "2025-01-01T10:00:00.000+0300",
+						Assignee: strPtr("Alice"),
+						Reporter: "Bob",
+						Priority: "High",
+					},
+					{
+						Key:      "T2",
+						Status:   "In Progress",
+						Type:     "Bug",
+						Project:  "P1",
+						Created:  "2025-01-01T10:00:00.000+0300",
+						Updated:  "2025-01-01T10:00:00.000+0300",
+						Assignee: strPtr("Charlie"),
+						Reporter: "Dave",
+						Priority: "Low",
+					},
+					{
+						Key:      "T3",
+						Status:   "Done",
+						Type:     "Story",
+						Project:  "P2",
+						Created:  "2025-01-01T10:00:00.000+0300",
+						Updated:  "2025-01-01T10:00:00.000+0300",
+						Assignee: nil,
+						Reporter: "Eve",
+						Priority: "Medium",
+					},
+				},
+			},
+			checks: []string{
+				"Total tasks: 3",
+				"| T1 | Done | Task | High | Alice |",
+				"| T2 | In Progress | Bug | Low | Charlie |",
+				"| T3 | Done | Story | Medium | Unassigned |",
+				"| Done | 2 |",
+				"| In Progress | 1 |",
+			},
+		},
+		{
+			name: "Task with sprints",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key:      "SPR-1",
+						Status:   "Done",
+						Type:     "Story",
+						Project:  "SPR",
+						Priority: "Medium",
+						Sprints:  []string{"Sprint 1", "Sprint 2"},
+					},
+				},
+			},
+			checks: []string{
+				"- **Sprints:** Sprint 1, Sprint 2",
+			},
+		},
+		{
+			name:     "Empty task list",
+			response: TransitionCheckResponse{Tasks: []JiraTransitionResult{}},
+			checks: []string{
+				"Total tasks: 0",
+				"Total transitions: 0",
+				"## Summary",
+				"## Task Details",
+				"## Status Distribution",
+			},
+		},
+		{
+			name: "Task with multiline description and link",
+			response: TransitionCheckResponse{
+				Tasks: []JiraTransitionResult{
+					{
+						Key:         "MULTI-123",
+						Link:        "https://test.atlassian.net/browse/MULTI-123",
+						Status:      "Open",
+						Description: "Line 1\nLine 2\nLine 3",
+						Type:        "Task",
+						Project:     "TEST",
+						Created:     "2025-01-01T10:00:00.000+0300",
+						Updated:     "2025-01-01T10:00:00.000+0300",
+						Assignee:    strPtr("Test User"),
+						Reporter:    "Reporter",
+						Priority:    "Low",
+						Transitions: []Transition{},
+					},
+				},
+			},
+			checks: []string{
+				"> Line 1\n> Line 2\n> Line 3",
+				"[MULTI-123](https://test.atlassian.net/browse/MULTI-123)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			markdown := generateMarkdown(tt.response, MarkdownOptions{})
+
+			// Check that all expected strings are present
+			for _, check := range tt.checks {
+				assert.Contains(t, markdown, check, "Expected to find: %s", check)
+			}
+
+			// Errors section only appears when there are error tasks
+			hasErrors := false
+			for _, task := range tt.response.Tasks {
+				hasErrors = hasErrors || task.Status == ErrorStatus
+			}
+			assert.Equal(t, hasErrors, strings.Contains(markdown, "## Errors"))
+
+			// Basic structure checks
+			assert.True(t, strings.HasPrefix(markdown, "# JIRA Tasks Report"))
+			assert.Contains(t, markdown, "Generated on:")
+			assert.Contains(t, markdown, "## Summary")
+			assert.Contains(t, markdown, "## Task Details")
+			assert.Contains(t, markdown, "## Status Distribution")
+		})
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		options  MarkdownOptions
+		expected string
+	}{
+		{
+			name:     "Valid JIRA date",
+			input:    "2025-01-01T10:30:45.123+0300",
+			expected: "2025-01-01 10:30:45",
+		},
+		{
+			name:     "Empty string",
+			input:    "",
+			expected: "N/A",
+		},
+		{
+			name:     "Invalid date format",
+			input:    "not-a-date",
+			expected: "not-a-date",
+		},
+		{
+			name:     "Different timezone",
+			input:    "2025-12-31T23:59:59.999-0500",
+			expected: "2025-12-31 23:59:59",
+		},
+		{
+			name:     "Date-only layout",
+			input:    "2025-01-01T10:30:45.123+0300",
+			options:  MarkdownOptions{DateFormat: "2006-01-02"},
+			expected: "2025-01-01",
+		},
+		{
+			name:     "RFC3339 layout keeps the offset",
+			input:    "2025-01-01T10:30:45.123+0300",
+			options:  MarkdownOptions{DateFormat: time.RFC3339},
+			expected: "2025-01-01T10:30:45+03:00",
+		},
+		{
+			name:     "Converted to UTC",
+			input:    "2025-01-01T10:30:45.123+0300",
+			options:  MarkdownOptions{Location: time.UTC},
+			expected: "2025-01-01 07:30:45",
+		},
+		{
+			name:     "Converted across the date line",
+			input:    "2025-12-31T23:59:59.999-0500",
+			options:  MarkdownOptions{Location: time.FixedZone("UTC+9", 9*60*60)},
+			expected: "2026-01-01 13:59:59",
+		},
+		{
+			name:     "Conversion combined with an offset-aware layout",
+			input:    "2025-01-01T10:30:45.123+0300",
+			options:  MarkdownOptions{DateFormat: time.RFC3339, Location: time.FixedZone("", -5*60*60)},
+			expected: "2025-01-01T02:30:45-05:00",
+		},
+		{
+			name:     "Custom layout does not affect empty dates",
+			input:    "",
+			options:  MarkdownOptions{DateFormat: "02/01/2006"},
+			expected: "N/A",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatDate(tt.input, tt.options)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestResolveDateFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    string
+		expectError bool
+	}{
+		{name: "Empty uses default", input: "", expected: DefaultDateFormat},
+		{name: "iso preset", input: "iso", expected: "2006-01-02T15:04:05-07:00"},
+		{name: "date preset", input: "date", expected: "2006-01-02"},
+		{name: "rfc3339 preset is case-insensitive", input: "RFC3339", expected: time.RFC3339},
+		{name: "Custom Go layout", input: "02 Jan 2006 15:04", expected: "02 Jan 2006 15:04"},
+		{name: "Layout without reference elements", input: "yyyy-mm-dd", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			layout, err := ResolveDateFormat(tt.input)
+			if tt.expectError {
+				var validationErr *ValidationError
+				assert.ErrorAs(t, err, &validationErr)
+				assert.Equal(t, "date-format", validationErr.Field)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, layout)
+		})
+	}
+}
+
+func TestGenerateMarkdownResolution(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Resolution: "Fixed", ResolutionDate: "2024-03-04T05:06:07.000+0000"},
+			{Key: "EV-2", Status: "Done"},
+			{Key: "EV-3", Status: ErrorStatus, Description: "Error 404: Could not retrieve issue"},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{DateFormat: "2006-01-02"})
+
+	assert.Contains(t, markdown, "- **Resolution:** Fixed\n")
+	assert.Contains(t, markdown, "- **Resolved:** 2024-03-04\n")
+	assert.Contains(t, markdown, "- **Resolution:** Unresolved\n")
+	assert.Equal(t, 2, strings.Count(markdown, "- **Resolution:**"), "error results have no resolution")
+	assert.Equal(t, 1, strings.Count(markdown, "- **Resolved:**"))
+}
+
+func TestGenerateMarkdownSecurityLevel(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", SecurityLevel: "Internal"},
+			{Key: "EV-2", Status: "Done"},
+			{Key: "EV-3", Status: ErrorStatus, Description: "Error 404: Could not retrieve issue"},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{})
+
+	assert.Contains(t, markdown, "- **Security Level:** Internal\n")
+	assert.Contains(t, markdown, "- **Security Level:** \n")
+	assert.Equal(t, 2, strings.Count(markdown, "- **Security Level:**"), "error results have no security level")
+}
+
+func TestGenerateMarkdownStatusCategory(t *testing.T) {
+	t.Run("counts categories in order with unknown last", func(t *testing.T) {
+		response := TransitionCheckResponse{
+			Tasks: []JiraTransitionResult{
+				{Key: "EV-1", Status: "Closed", StatusCategory: "Done"},
+				{Key: "EV-2", Status: "QA", StatusCategory: "In Progress"},
+				{Key: "EV-3", Status: "Review", StatusCategory: "In Progress"},
+				{Key: "EV-4", Status: "Backlog", StatusCategory: "To Do"},
+				{Key: "EV-5", Status: "Erledigt", StatusCategory: "Fertig"},
+				{Key: "EV-6", Status: ErrorStatus, Description: "Error 404: Could not retrieve issue"},
+			},
+		}
+
+		markdown := generateMarkdown(response, MarkdownOptions{})
+
+		assert.Contains(t, markdown, "## Status Category Distribution\n\n| Category | Count |\n|----------|-------|\n"+
+			"| To Do | 1 |\n| In Progress | 2 |\n| Done | 1 |\n| Fertig | 1 |\n| Unknown | 1 |\n")
+	})
+
+	t.Run("left out without category data", func(t *testing.T) {
+		response := TransitionCheckResponse{
+			Tasks: []JiraTransitionResult{{Key: "EV-1", Status: "Done"}},
+		}
+
+		markdown := generateMarkdown(response, MarkdownOptions{})
+
+		assert.Contains(t, markdown, "## Status Distribution")
+		assert.NotContains(t, markdown, "## Status Category Distribution")
+	})
+}
+
+func TestGenerateMarkdownRequestedKey(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "NEW-5", Status: "Done", RequestedKey: "OLD-1"},
+			{Key: "EV-2", Status: "Done"},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{})
+
+	assert.Contains(t, markdown, "- **Requested As:** OLD-1 (moved)\n")
+	assert.Equal(t, 1, strings.Count(markdown, "Requested As"))
+}
+
+func TestTicketAge(t *testing.T) {
+	now := time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		task     JiraTransitionResult
+		expected string
+	}{
+		{
+			name:     "Unresolved ticket is aged until now",
+			task:     JiraTransitionResult{Created: "2025-03-07T08:30:00.000+0000"},
+			expected: "P3DT3H",
+		},
+		{
+			name:     "Resolved ticket is aged until its resolution",
+			task:     JiraTransitionResult{Created: "2025-01-01T10:00:00.000+0300", ResolutionDate: "2025-01-15T10:00:00.000+0300"},
+			expected: "P14D",
+		},
+		{
+			name:     "Less than a day",
+			task:     JiraTransitionResult{Created: "2025-03-10T06:59:00.000+0000"},
+			expected: "PT5H",
+		},
+		{
+			name:     "Created in the future counts as zero",
+			task:     JiraTransitionResult{Created: "2025-03-11T12:00:00.000+0000"},
+			expected: "PT0H",
+		},
+		{
+			name:     "Missing created date",
+			task:     JiraTransitionResult{Key: "EV-1", Status: ErrorStatus},
+			expected: "N/A",
+		},
+		{
+			name:     "Unparseable resolution date",
+			task:     JiraTransitionResult{Created: "2025-03-07T08:30:00.000+0000", ResolutionDate: "yesterday"},
+			expected: "N/A",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, ticketAge(tt.task, now))
+		})
+	}
+}
+
+func TestResolveSortBy(t *testing.T) {
+	for _, value := range []string{"", "key", "status", "priority", "created"} {
+		sortBy, err := ResolveSortBy(value)
+		assert.NoError(t, err)
+		assert.Equal(t, value, sortBy)
+	}
+
+	sortBy, err := ResolveSortBy("Created")
+	assert.NoError(t, err)
+	assert.Equal(t, SortByCreated, sortBy)
+
+	_, err = ResolveSortBy("assignee")
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "sort-by", validationErr.Field)
+}
+
+func TestSortTasks(t *testing.T) {
+	tasks := []JiraTransitionResult{
+		{Key: "EV-10", Status: "Done", Priority: "Low", Created: "2024-03-01T10:00:00.000+0000"},
+		{Key: "OPS-1", Status: "", Priority: "", Created: ""},
+		{Key: "EV-2", Status: "backlog", Priority: "Highest", Created: "2024-01-01T10:00:00.000+0000"},
+		{Key: "EV-7", Status: "In Progress", Priority: "Urgent-ish", Created: "2024-02-01T10:00:00.000+0000"},
+		{Key: "EV-3", Status: "Done", Priority: "medium", Created: "2023-12-01T10:00:00.000+0000"},
+	}
+
+	keys := func(tasks []JiraTransitionResult) []string {
+		var keys []string
+		for _, task := range tasks {
+			keys = append(keys, task.Key)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		sortBy   string
+		expected []string
+	}{
+		{sortBy: "", expected: []string{"EV-10", "OPS-1", "EV-2", "EV-7", "EV-3"}},
+		{sortBy: SortByKey, expected: []string{"EV-2", "EV-3", "EV-7", "EV-10", "OPS-1"}},
+		{sortBy: SortByStatus, expected: []string{"EV-2", "EV-10", "EV-3", "EV-7", "OPS-1"}},
+		{sortBy: SortByPriority, expected: []string{"EV-2", "EV-3", "EV-10", "EV-7", "OPS-1"}},
+		{sortBy: SortByCreated, expected: []string{"EV-3", "EV-2", "EV-7", "EV-10", "OPS-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run("sort by "+tt.sortBy, func(t *testing.T) {
+			assert.Equal(t, tt.expected, keys(sortTasks(tasks, tt.sortBy)))
+		})
+	}
+
+	// The input keeps its order
+	assert.Equal(t, []string{"EV-10", "OPS-1", "EV-2", "EV-7", "EV-3"}, keys(tasks))
+}
+
+func TestGenerateMarkdownSortBy(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Priority: "Low"},
+			{Key: "EV-2", Status: "Done", Priority: "High"},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{SortBy: SortByPriority})
+
+	assert.Less(t, strings.Index(markdown, "| EV-2 |"), strings.Index(markdown, "| EV-1 |"))
+	assert.Less(t, strings.Index(markdown, "### 1. EV-2"), strings.Index(markdown, "### 2. EV-1"))
+}
+
+// Test that the "Generated on" line uses the configured date format
+func TestGenerateMarkdownUsesDateFormat(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Created: "2025-03-04T05:06:07.000+0000"},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{DateFormat: "2006-01-02"})
+
+	assert.Contains(t, markdown, "Generated on: "+time.Now().Format("2006-01-02")+"\n")
+	assert.Contains(t, markdown, "- **Created:** 2025-03-04\n")
+}
+
+func TestGenerateMarkdownAnonymize(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{
+				Key:      "EV-1",
+				Status:   "Done",
+				Assignee: strPtr("Jane Doe"),
+				Reporter: "John Smith",
+				Transitions: []Transition{
+					{FromStatus: "To Do", ToStatus: "Done", Author: "Jane Doe", AuthorEmail: "jane@example.com"},
+				},
+			},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{Anonymize: true})
+
+	assert.NotContains(t, markdown, "Jane Doe")
+	assert.NotContains(t, markdown, "John Smith")
+	assert.Contains(t, markdown, "- **Assignee:** User A\n")
+	assert.Contains(t, markdown, "- **Reporter:** User B\n")
+	assert.Contains(t, markdown, "| To Do | Done | User A |")
+}
+
+func TestGenerateMarkdownTOC(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "EV-10", Status: "Done", Link: "https://example.atlassian.net/browse/EV-10"},
+			{Key: "OPS_APP-2", Status: "Done"},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{TOC: true})
+
+	assert.Contains(t, markdown, "## Contents\n\n- [EV-10](#1-ev-10)\n- [OPS_APP-2](#2-ops_app-2)\n\n## Summary")
+	assert.Contains(t, markdown, "### 1. [EV-10](https://example.atlassian.net/browse/EV-10)\n")
+	assert.Less(t, strings.Index(markdown, "## Contents"), strings.Index(markdown, "## Summary"))
+
+	assert.NotContains(t, generateMarkdown(response, MarkdownOptions{}), "## Contents", "the table of contents is opt-in")
+}
+
+func TestResolveGroupBy(t *testing.T) {
+	for _, value := range []string{"", "project", "Project"} {
+		groupBy, err := ResolveGroupBy(value)
+		assert.NoError(t, err)
+		assert.Equal(t, strings.ToLower(value), groupBy)
+	}
+
+	_, err := ResolveGroupBy("assignee")
+	var validationErr *ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "group-by", validationErr.Field)
+}
+
+func TestGenerateMarkdownGroupByProject(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{Key: "OPS-2", Project: "OPS", Status: "Done"},
+			{Key: "EV-10", Status: ErrorStatus, Type: ErrorType},
+			{Key: "EV-3", Project: "EV", Status: "Done"},
+			{Key: "OPS-1", Project: "OPS", Status: "Done"},
+			{Key: "EV-1", Project: "EV", Status: "Done"},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{GroupBy: GroupByProject, SortBy: SortByKey, TOC: true})
+
+	assert.NotContains(t, markdown, "## Task Details")
+	assert.Contains(t, markdown, "## Project: EV\n\n### 1. EV-1\n")
+	assert.Contains(t, markdown, "### 2. EV-3\n")
+	assert.Contains(t, markdown, "## Project: OPS\n\n### 3. OPS-1\n")
+	assert.Contains(t, markdown, "### 4. OPS-2\n")
+	assert.Contains(t, markdown, "## Project: Unknown\n\n### 5. EV-10\n")
+	assert.Equal(t, 1, strings.Count(markdown, "## Project: EV\n"), "each project gets one heading")
+	assert.Contains(t, markdown, "- [EV-1](#1-ev-1)\n- [EV-3](#2-ev-3)\n- [OPS-1](#3-ops-1)\n", "the contents follow the grouped order")
+
+	flat := generateMarkdown(response, MarkdownOptions{})
+	assert.Contains(t, flat, "## Task Details\n\n### 1. OPS-2\n", "the flat layout is the default")
+	assert.NotContains(t, flat, "## Project:")
+}
+
+func TestGenerateSplitMarkdown(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{
+				Key:         "EV-10",
+				Status:      "Done",
+				Link:        "https://example.atlassian.net/browse/EV-10",
+				Assignee:    strPtr("Jane Doe"),
+				Transitions: []Transition{{FromStatus: "To Do", ToStatus: "Done", Author: "Jane Doe", TransitionTime: "2024-01-15T10:00:00.000+0000"}},
+			},
+			{Key: "OPS#7", Status: "In Progress"},
+			{Key: "OPS_7", Status: "To Do"},
+		},
+	}
+	outputDir := filepath.Join(t.TempDir(), "wiki")
+
+	require.NoError(t, GenerateSplitMarkdown(response, outputDir, MarkdownOptions{SortBy: SortByKey}))
+
+	ticket, err := os.ReadFile(filepath.Join(outputDir, "EV-10.md"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(ticket), "# [EV-10](https://example.atlassian.net/browse/EV-10)\n\n**Basic Information:**\n"))
+	assert.Contains(t, string(ticket), "- **Assignee:** Jane Doe\n")
+	assert.Contains(t, string(ticket), "| To Do | Done | Jane Doe | 2024-01-15 10:00:00 |\n")
+
+	// The same rendering as the task details of the single-file report
+	report := generateMarkdown(response, MarkdownOptions{SortBy: SortByKey})
+	assert.Contains(t, report, strings.TrimPrefix(string(ticket), "# [EV-10](https://example.atlassian.net/browse/EV-10)\n\n"))
+
+	// Keys that sanitize alike get distinct files
+	sanitized, err := os.ReadFile(filepath.Join(outputDir, "OPS_7.md"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(sanitized), "# OPS#7\n"))
+	deduplicated, err := os.ReadFile(filepath.Join(outputDir, "OPS_7-2.md"))
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(string(deduplicated), "# OPS_7\n"))
+
+	index, err := os.ReadFile(filepath.Join(outputDir, SplitIndexFile))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), "Total tasks: 3\n")
+	assert.Contains(t, string(index), "| [EV-10](EV-10.md) | Done |  |  | Jane Doe | 1 | N/A |\n")
+	assert.Contains(t, string(index), "| [OPS#7](OPS_7.md) | In Progress |")
+	assert.Contains(t, string(index), "| [OPS_7](OPS_7-2.md) | To Do |")
+}
+
+func TestTicketFileName(t *testing.T) {
+	used := map[string]bool{}
+	assert.Equal(t, "EV-123.md", ticketFileName("EV-123", used))
+	assert.Equal(t, "EV-123-2.md", ticketFileName("EV-123", used))
+	assert.Equal(t, "___etc_passwd.md", ticketFileName("../etc/passwd", used))
+	assert.Equal(t, "_.md", ticketFileName("", used))
+	assert.Equal(t, "index-2.md", ticketFileName("index", used))
+	assert.Equal(t, "T_K_T-1.md", ticketFileName("TÄKÉT-1", map[string]bool{}))
+}
+
+func TestHeadingAnchor(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{"1. EV-1", "1-ev-1"},
+		{"12. OPS_APP-345", "12-ops_app-345"},
+		{"Task Details", "task-details"},
+		{"Status: Done (QA)!", "status-done-qa"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, headingAnchor(tt.text))
+	}
+}
+
+// Helper function to create string pointer
+func strPtr(s string) *string {
+	return &s
+}
+
+// Test that generated markdown includes current time
+func TestGenerateMarkdownIncludesCurrentTime(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{})
+
+	// Check that it includes a date in the expected format
+	now := time.Now()
+	yearStr := now.Format("2006")
+	assert.Contains(t, markdown, yearStr)
+	assert.Contains(t, markdown, "Generated on:")
+}
+
+// Test markdown formatting edge cases
+func TestMarkdownFormattingEdgeCases(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{
+				Key:         "EDGE-1",
+				Status:      "Status|With|Pipes",
+				Description: "Description with | pipes and **markdown**",
+				Type:        "Type*With*Stars",
+				Project:     "PROJ",
+				Created:     "2025-01-01T10:00:00.000+0300",
+				Updated:     "2025-01-01T10:00:00.000+0300",
+				Assignee:    strPtr("User|With|Pipes"),
+				Reporter:    "Reporter**Bold**",
+				Priority:    "Priority`Code`",
+				Transitions: []Transition{
+					{
+						FromStatus:     "From|Status",
+						ToStatus:       "To|Status",
+						Author:         "Author|Name",
+						AuthorEmail:    "email@with|pipe.com",
+						TransitionTime: "2025-01-01T11:00:00.000+0300",
+					},
+				},
+			},
+		},
+	}
+
+	markdown := generateMarkdown(response, MarkdownOptions{})
+
+	// Verify the special characters are preserved in the output
+	assert.Contains(t, markdown, "Status|With|Pipes")
+	assert.Contains(t, markdown, "Description with | pipes and **markdown**")
+	assert.Contains(t, markdown, "User|With|Pipes")
+	assert.Contains(t, markdown, "From|Status")
+	assert.Contains(t, markdown, "To|Status")
+}
@@ -0,0 +1,83 @@
+package evidence
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	jira "github.com/andygrunwald/go-jira/v2/cloud"
+)
+
+// ProjectStatusGetter lists the statuses used by a project's workflows, per issue type.
+// go-jira has no call for it, so JiraClient implements it on top of *jira.Client; tests inject a fake.
+type ProjectStatusGetter interface {
+	GetProjectStatuses(ctx context.Context, projectKey string) ([]IssueTypeStatuses, *jira.Response, error)
+}
+
+// IssueTypeStatuses is one issue type of a project with the statuses of its workflow
+type IssueTypeStatuses struct {
+	Name     string        `json:"name"`
+	Statuses []jira.Status `json:"statuses"`
+}
+
+// ProjectStatus is a status used in a project, with the issue types whose workflow contains it
+type ProjectStatus struct {
+	Name       string
+	Category   string
+	IssueTypes []string
+}
+
+// projectStatusService gets a project's statuses from /rest/api/2/project/{key}/statuses
+type projectStatusService struct {
+	client *jira.Client
+}
+
+func (s *projectStatusService) GetProjectStatuses(ctx context.Context, projectKey string) ([]IssueTypeStatuses, *jira.Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/project/%s/statuses", url.PathEscape(projectKey))
+	req, err := s.client.NewRequest(ctx, http.MethodGet, apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var issueTypes []IssueTypeStatuses
+	resp, err := s.client.Do(req, &issueTypes)
+	if err != nil {
+		return nil, resp, jira.NewJiraError(resp, err)
+	}
+	return issueTypes, resp, nil
+}
+
+// ListProjectStatuses returns the statuses of a project's workflows (--list-statuses), in the order
+// JIRA lists them, each with the issue types using it
+func (jc *JiraClient) ListProjectStatuses(projectKey string) ([]ProjectStatus, error) {
+	if jc.projects == nil {
+		return nil, fmt.Errorf("listing project statuses is not supported by this client")
+	}
+
+	issueTypes, resp, err := jc.projects.GetProjectStatuses(context.Background(), projectKey)
+	if err != nil {
+		statusCode := getHTTPStatusCode(resp)
+		switch statusCode {
+		case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+			// JIRA answers 404 rather than 403 for projects the user can't browse
+			err = fmt.Errorf("project does not exist or you do not have permission to browse it")
+		}
+		return nil, &JiraError{Key: projectKey, StatusCode: statusCode, Err: err}
+	}
+
+	var statuses []ProjectStatus
+	index := make(map[string]int)
+	for _, issueType := range issueTypes {
+		for _, status := range issueType.Statuses {
+			i, ok := index[status.Name]
+			if !ok {
+				i = len(statuses)
+				index[status.Name] = i
+				statuses = append(statuses, ProjectStatus{Name: status.Name, Category: status.StatusCategory.Name})
+			}
+			statuses[i].IssueTypes = append(statuses[i].IssueTypes, issueType.Name)
+		}
+	}
+	return statuses, nil
+}
@@ -0,0 +1,82 @@
+package evidence
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	jira "github.com/andygrunwald/go-jira/v2/cloud"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJiraClient_ListProjectStatuses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/project/EV/statuses":
+			fmt.Fprint(w, `[`+
+				`{"name":"Task","statuses":[{"name":"To Do","statusCategory":{"name":"To Do"}},{"name":"In Progress","statusCategory":{"name":"In Progress"}},{"name":"Done","statusCategory":{"name":"Done"}}]},`+
+				`{"name":"Bug","statuses":[{"name":"To Do","statusCategory":{"name":"To Do"}},{"name":"QA","statusCategory":{"name":"In Progress"}},{"name":"Done","statusCategory":{"name":"Done"}}]}`+
+				`]`)
+		case "/rest/api/2/project/EMPTY/statuses":
+			fmt.Fprint(w, `[]`)
+		case "/rest/api/2/project/DOWN/statuses":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"errorMessages":["No project could be found with key 'SECRET'."]}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := jira.NewClient(server.URL, server.Client())
+	require.NoError(t, err)
+	jiraClient := &JiraClient{issues: client.Issue, projects: &projectStatusService{client: client}, baseURL: server.URL}
+
+	t.Run("statuses merged across issue types", func(t *testing.T) {
+		statuses, err := jiraClient.ListProjectStatuses("EV")
+
+		require.NoError(t, err)
+		assert.Equal(t, []ProjectStatus{
+			{Name: "To Do", Category: "To Do", IssueTypes: []string{"Task", "Bug"}},
+			{Name: "In Progress", Category: "In Progress", IssueTypes: []string{"Task"}},
+			{Name: "Done", Category: "Done", IssueTypes: []string{"Task", "Bug"}},
+			{Name: "QA", Category: "In Progress", IssueTypes: []string{"Bug"}},
+		}, statuses)
+	})
+
+	t.Run("project without statuses", func(t *testing.T) {
+		statuses, err := jiraClient.ListProjectStatuses("EMPTY")
+
+		assert.NoError(t, err)
+		assert.Empty(t, statuses)
+	})
+
+	t.Run("project not found or not accessible", func(t *testing.T) {
+		statuses, err := jiraClient.ListProjectStatuses("SECRET")
+
+		var jiraErr *JiraError
+		require.True(t, errors.As(err, &jiraErr))
+		assert.Equal(t, "SECRET", jiraErr.Key)
+		assert.Equal(t, http.StatusNotFound, jiraErr.StatusCode)
+		assert.Contains(t, err.Error(), "do not have permission")
+		assert.Nil(t, statuses)
+	})
+
+	t.Run("server error", func(t *testing.T) {
+		_, err := jiraClient.ListProjectStatuses("DOWN")
+
+		var jiraErr *JiraError
+		require.True(t, errors.As(err, &jiraErr))
+		assert.Equal(t, http.StatusInternalServerError, jiraErr.StatusCode)
+		assert.NotContains(t, err.Error(), "permission")
+	})
+
+	t.Run("client without project API", func(t *testing.T) {
+		_, err := (&JiraClient{issues: client.Issue}).ListProjectStatuses("EV")
+
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,89 @@
+package evidence
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// rawIssuePath matches the JIRA endpoints whose responses hold issues: a single issue or a search
+var rawIssuePath = regexp.MustCompile(`/rest/api/[0-9]+/(issue/[^/]+|search)$`)
+
+// rawDumpTransport writes the raw JSON of every issue JIRA returns to dir/KEY.json (--dump-raw),
+// for troubleshooting fields that are not extracted as expected
+type rawDumpTransport struct {
+	base http.RoundTripper
+	dir  string
+}
+
+func (t *rawDumpTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK || !rawIssuePath.MatchString(req.URL.Path) {
+		return resp, err
+	}
+
+	// Key-only searches (--jql) are followed by the real fetches, which are the ones worth keeping
+	isSearch := filepath.Base(req.URL.Path) == "search"
+	if isSearch && req.URL.Query().Get("expand") == "" {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if isSearch {
+		var results struct {
+			Issues []json.RawMessage `json:"issues"`
+		}
+		if err := json.Unmarshal(body, &results); err != nil {
+			PrintWarning("Could not dump the raw search response: %v", err)
+			return resp, nil
+		}
+		for _, issue := range results.Issues {
+			t.dump(issue)
+		}
+	} else {
+		t.dump(body)
+	}
+	return resp, nil
+}
+
+// dump writes one issue's JSON, indented, to a file named after its key
+func (t *rawDumpTransport) dump(raw []byte) {
+	var issue struct {
+		Key string `json:"key"`
+	}
+	if err := json.Unmarshal(raw, &issue); err != nil || issue.Key == "" {
+		PrintWarning("Could not dump a raw JIRA issue without a key")
+		return
+	}
+
+	var indented bytes.Buffer
+	if err := json.Indent(&indented, raw, "", "  "); err != nil {
+		indented.Reset()
+		indented.Write(raw)
+	}
+	indented.WriteByte('\n')
+
+	file := filepath.Join(t.dir, sanitizeFileName(issue.Key)+".json")
+	if err := os.WriteFile(file, indented.Bytes(), 0644); err != nil {
+		PrintWarning("Could not dump the raw JSON of %s: %v", issue.Key, err)
+	}
+}
+
+// newRawDumpTransport creates the --dump-raw directory and the transport writing into it
+func newRawDumpTransport(base http.RoundTripper, dir string) (*rawDumpTransport, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, &ValidationError{Field: "dump-raw", Value: dir, Err: fmt.Errorf("cannot create directory: %w", err)}
+	}
+	return &rawDumpTransport{base: base, dir: dir}, nil
+}
@@ -0,0 +1,81 @@
+package evidence
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawDumpTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/issue/EV-1":
+			w.Write([]byte(`{"key":"EV-1","fields":{"customfield_10042":"odd value"}}`))
+		case "/rest/api/2/issue/EV-404":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errorMessages":["Issue does not exist"]}`))
+		case "/rest/api/2/issue/EV-1/comment":
+			w.Write([]byte(`{"key":"COMMENTS"}`))
+		case "/rest/api/2/search":
+			w.Write([]byte(`{"issues":[{"key":"EV-2","fields":{}},{"key":"OPS#3","fields":{}}]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "raw", "nested")
+	transport, err := newRawDumpTransport(http.DefaultTransport, dir)
+	require.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	get := func(path string) string {
+		resp, err := client.Get(server.URL + path)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		return string(body)
+	}
+
+	// The caller still reads the whole body
+	assert.Equal(t, `{"key":"EV-1","fields":{"customfield_10042":"odd value"}}`, get("/rest/api/2/issue/EV-1"))
+	get("/rest/api/2/issue/EV-404")
+	get("/rest/api/2/issue/EV-1/comment")
+	get("/rest/api/2/search?jql=key+in+(EV-2)&expand=changelog")
+
+	dumped, err := os.ReadFile(filepath.Join(dir, "EV-1.json"))
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"key\": \"EV-1\",\n  \"fields\": {\n    \"customfield_10042\": \"odd value\"\n  }\n}\n", string(dumped))
+
+	// Each issue of a search gets its own file
+	assert.FileExists(t, filepath.Join(dir, "EV-2.json"))
+	assert.FileExists(t, filepath.Join(dir, "OPS_3.json"))
+
+	// Errors and other endpoints are not dumped
+	assert.NoFileExists(t, filepath.Join(dir, "EV-404.json"))
+	assert.NoFileExists(t, filepath.Join(dir, "COMMENTS.json"))
+
+	t.Run("Key-only searches are not dumped", func(t *testing.T) {
+		os.Remove(filepath.Join(dir, "EV-2.json"))
+		get("/rest/api/2/search?jql=project+%3D+EV&fields=key")
+		assert.NoFileExists(t, filepath.Join(dir, "EV-2.json"))
+	})
+}
+
+func TestNewRawDumpTransportInvalidDir(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0644))
+
+	_, err := newRawDumpTransport(http.DefaultTransport, filepath.Join(file, "raw"))
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "dump-raw", validationErr.Field)
+}
@@ -0,0 +1,114 @@
+package evidence
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// reportView is a response prepared for display: sorted, anonymized if requested, with dates
+// formatted and blanks filled in. The HTML and text reports render it with the same sections
+// as the markdown report.
+type reportView struct {
+	GeneratedOn      string
+	TotalTransitions int
+	Tasks            []reportTask
+	Errors           []reportTask
+	StatusCounts     []reportStatusCount
+}
+
+type reportTask struct {
+	Key          string
+	Link         string
+	Status       string
+	Type         string
+	Project      string
+	Priority     string
+	Resolution   string
+	ReferencedAs string
+	RequestedKey string
+	Sprints      string
+	Assignee     string
+	Reporter     string
+	Created      string
+	Updated      string
+	Resolved     string
+	Description  string
+	Links        []string
+	Transitions  []reportTransition
+}
+
+type reportTransition struct {
+	FromStatus string
+	ToStatus   string
+	Author     string
+	Date       string
+}
+
+type reportStatusCount struct {
+	Status string
+	Count  int
+}
+
+// newReportView prepares a response for the HTML and text reports. The markdown options
+// (date format, timezone, sorting, anonymizing) apply.
+func newReportView(response TransitionCheckResponse, options MarkdownOptions) reportView {
+	if options.Anonymize {
+		response = Anonymize(response)
+	}
+
+	report := reportView{GeneratedOn: options.formatTime(time.Now())}
+	statusCounts := make(map[string]int)
+	for _, task := range sortTasks(response.Tasks, options.SortBy) {
+		view := reportTask{
+			Key:          task.Key,
+			Link:         task.Link,
+			Status:       task.Status,
+			Type:         task.Type,
+			Project:      task.Project,
+			Priority:     task.Priority,
+			ReferencedAs: task.ReferencedAs,
+			RequestedKey: task.RequestedKey,
+			Sprints:      strings.Join(task.Sprints, ", "),
+			Assignee:     "Unassigned",
+			Reporter:     task.Reporter,
+			Created:      formatDate(task.Created, options),
+			Updated:      formatDate(task.Updated, options),
+			Description:  task.Description,
+		}
+		if task.Status != ErrorStatus {
+			view.Resolution = getOrDefault(task.Resolution, "Unresolved")
+		}
+		if task.ResolutionDate != "" {
+			view.Resolved = formatDate(task.ResolutionDate, options)
+		}
+		if task.Assignee != nil && *task.Assignee != "" {
+			view.Assignee = *task.Assignee
+		}
+		for _, link := range task.Links {
+			view.Links = append(view.Links, getOrDefault(link.Relation, link.Type)+" "+link.Key)
+		}
+		for _, transition := range task.Transitions {
+			view.Transitions = append(view.Transitions, reportTransition{
+				FromStatus: transition.FromStatus,
+				ToStatus:   transition.ToStatus,
+				Author:     transition.Author,
+				Date:       formatDate(transition.TransitionTime, options),
+			})
+		}
+
+		report.Tasks = append(report.Tasks, view)
+		if task.Status == ErrorStatus {
+			report.Errors = append(report.Errors, view)
+		}
+		report.TotalTransitions += len(task.Transitions)
+		statusCounts[task.Status]++
+	}
+
+	for status, count := range statusCounts {
+		report.StatusCounts = append(report.StatusCounts, reportStatusCount{Status: status, Count: count})
+	}
+	sort.Slice(report.StatusCounts, func(i, j int) bool { return report.StatusCounts[i].Status < report.StatusCounts[j].Status })
+
+	return report
+}
@@ -0,0 +1,130 @@
+package evidence
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Log formats for the run summary
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// RunStats collects timing and counters for a single run.
+// All methods are safe to call on a nil *RunStats, which records nothing.
+type RunStats struct {
+	start        time.Time
+	gitCommands  atomic.Int64
+	jiraAPICalls atomic.Int64
+	retries      atomic.Int64
+
+	mu              sync.Mutex
+	slowestFetch    time.Duration
+	slowestFetchKey string
+}
+
+// RunSummary is the JSON representation of the run statistics
+type RunSummary struct {
+	DurationMs   int64 `json:"duration_ms"`
+	GitCommands  int64 `json:"git_commands"`
+	JiraAPICalls int64 `json:"jira_api_calls"`
+	Retries      int64 `json:"retries"`
+
+	// SlowestFetchMs and SlowestFetchKey identify the single-ticket fetch that took longest,
+	// typically a ticket with an enormous changelog
+	SlowestFetchMs  int64  `json:"slowest_fetch_ms"`
+	SlowestFetchKey string `json:"slowest_fetch_key,omitempty"`
+}
+
+// NewRunStats creates a stats collector whose duration starts now
+func NewRunStats() *RunStats {
+	return &RunStats{start: time.Now()}
+}
+
+func (s *RunStats) recordGitCommand() {
+	if s != nil {
+		s.gitCommands.Add(1)
+	}
+}
+
+func (s *RunStats) recordJiraAPICall() {
+	if s != nil {
+		s.jiraAPICalls.Add(1)
+	}
+}
+
+func (s *RunStats) recordRetry() {
+	if s != nil {
+		s.retries.Add(1)
+	}
+}
+
+// recordFetch keeps track of the slowest single-ticket fetch
+func (s *RunStats) recordFetch(key string, elapsed time.Duration) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if elapsed > s.slowestFetch {
+		s.slowestFetch = elapsed
+		s.slowestFetchKey = key
+	}
+}
+
+// Summary returns a snapshot of the collected statistics
+func (s *RunStats) Summary() RunSummary {
+	if s == nil {
+		return RunSummary{}
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return RunSummary{
+		DurationMs:      time.Since(s.start).Milliseconds(),
+		GitCommands:     s.gitCommands.Load(),
+		JiraAPICalls:    s.jiraAPICalls.Load(),
+		Retries:         s.retries.Load(),
+		SlowestFetchMs:  s.slowestFetch.Milliseconds(),
+		SlowestFetchKey: s.slowestFetchKey,
+	}
+}
+
+// PrintRunSummary writes the run statistics as a text block or a single JSON line
+func PrintRunSummary(w io.Writer, stats *RunStats, logFormat string) {
+	summary := stats.Summary()
+
+	if logFormat == LogFormatJSON {
+		jsonBytes, err := json.Marshal(summary)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(w, string(jsonBytes))
+		return
+	}
+
+	fmt.Fprintln(w, "\n=== Run Summary ===")
+	fmt.Fprintf(w, "Duration: %s\n", time.Duration(summary.DurationMs)*time.Millisecond)
+	fmt.Fprintf(w, "Git commands: %d\n", summary.GitCommands)
+	fmt.Fprintf(w, "JIRA API calls: %d\n", summary.JiraAPICalls)
+	fmt.Fprintf(w, "Retries: %d\n", summary.Retries)
+	if summary.SlowestFetchKey != "" {
+		fmt.Fprintf(w, "Slowest ticket fetch: %s (%s)\n", summary.SlowestFetchKey, time.Duration(summary.SlowestFetchMs)*time.Millisecond)
+	}
+}
+
+// countingTransport counts the HTTP requests sent to JIRA
+type countingTransport struct {
+	base  http.RoundTripper
+	stats *RunStats
+}
+
+func (t *countingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.stats.recordJiraAPICall()
+	return t.base.RoundTrip(req)
+}
@@ -0,0 +1,117 @@
+package evidence
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunStats(t *testing.T) {
+	t.Run("counts events", func(t *testing.T) {
+		stats := NewRunStats()
+		stats.recordGitCommand()
+		stats.recordGitCommand()
+		stats.recordJiraAPICall()
+		stats.recordRetry()
+		stats.recordFetch("EV-1", 120*time.Millisecond)
+		stats.recordFetch("EV-2", 3*time.Second)
+		stats.recordFetch("EV-3", 40*time.Millisecond)
+
+		summary := stats.Summary()
+		assert.Equal(t, int64(3000), summary.SlowestFetchMs)
+		assert.Equal(t, "EV-2", summary.SlowestFetchKey)
+		assert.Equal(t, int64(2), summary.GitCommands)
+		assert.Equal(t, int64(1), summary.JiraAPICalls)
+		assert.Equal(t, int64(1), summary.Retries)
+		assert.GreaterOrEqual(t, summary.DurationMs, int64(0))
+	})
+
+	t.Run("nil stats records nothing", func(t *testing.T) {
+		var stats *RunStats
+		stats.recordGitCommand()
+		stats.recordJiraAPICall()
+		stats.recordRetry()
+		stats.recordFetch("EV-1", time.Second)
+
+		assert.Equal(t, RunSummary{}, stats.Summary())
+	})
+}
+
+func TestPrintRunSummary(t *testing.T) {
+	stats := NewRunStats()
+	stats.recordGitCommand()
+	stats.recordJiraAPICall()
+	stats.recordJiraAPICall()
+	stats.recordFetch("EV-7", 1500*time.Millisecond)
+
+	t.Run("text", func(t *testing.T) {
+		var buf bytes.Buffer
+		PrintRunSummary(&buf, stats, LogFormatText)
+
+		output := buf.String()
+		assert.Contains(t, output, "=== Run Summary ===")
+		assert.Contains(t, output, "Duration: ")
+		assert.Contains(t, output, "Git commands: 1\n")
+		assert.Contains(t, output, "JIRA API calls: 2\n")
+		assert.Contains(t, output, "Retries: 0\n")
+		assert.Contains(t, output, "Slowest ticket fetch: EV-7 (1.5s)\n")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		PrintRunSummary(&buf, stats, LogFormatJSON)
+
+		var summary map[string]interface{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &summary))
+		assert.Equal(t, float64(1), summary["git_commands"])
+		assert.Equal(t, float64(2), summary["jira_api_calls"])
+		assert.Equal(t, float64(0), summary["retries"])
+		assert.Equal(t, float64(1500), summary["slowest_fetch_ms"])
+		assert.Equal(t, "EV-7", summary["slowest_fetch_key"])
+		assert.Contains(t, summary, "duration_ms")
+	})
+}
+
+func TestGitServiceWithStats(t *testing.T) {
+	stats := NewRunStats()
+	git := (&GitService{
+		execCommand: createMockGitCommand(map[string]struct {
+			output string
+			err    error
+		}{
+			"[rev-parse --git-dir]": {output: ".git", err: nil},
+			"[rev-parse HEAD]":      {output: "abc123", err: nil},
+		}),
+	}).WithStats(stats)
+
+	assert.NoError(t, git.CheckRepository())
+	head, err := git.GetHeadCommit()
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", head)
+
+	assert.Equal(t, int64(2), stats.Summary().GitCommands)
+}
+
+func TestCountingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	stats := NewRunStats()
+	client := &http.Client{Transport: &countingTransport{base: http.DefaultTransport, stats: stats}}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	assert.Equal(t, int64(3), stats.Summary().JiraAPICalls)
+}
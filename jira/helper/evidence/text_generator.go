@@ -0,0 +1,128 @@
+package evidence
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// RenderText renders a response as a plain-text report with the same sections as the markdown
+// report, for pasting into tools that don't render markdown. Table columns are sized to their
+// content. The markdown options (date format, timezone, sorting, anonymizing) apply.
+func RenderText(response TransitionCheckResponse, options MarkdownOptions) string {
+	report := newReportView(response, options)
+
+	var sb strings.Builder
+	writeTextHeading(&sb, "JIRA Tasks Report", "=")
+	sb.WriteString(fmt.Sprintf("Generated on: %s\n", report.GeneratedOn))
+	sb.WriteString(fmt.Sprintf("Total tasks: %d\n", len(report.Tasks)))
+	sb.WriteString(fmt.Sprintf("Total transitions: %d\n", report.TotalTransitions))
+	if len(report.Errors) > 0 {
+		sb.WriteString(fmt.Sprintf("Tasks with errors: %d\n", len(report.Errors)))
+	}
+	sb.WriteString("\n")
+
+	writeTextHeading(&sb, "Summary", "-")
+	rows := [][]string{{"KEY", "STATUS", "TYPE", "PRIORITY", "ASSIGNEE", "TRANSITIONS"}}
+	for _, task := range report.Tasks {
+		rows = append(rows, []string{task.Key, task.Status, task.Type, task.Priority, task.Assignee, fmt.Sprint(len(task.Transitions))})
+	}
+	writeTextTable(&sb, "", rows)
+	sb.WriteString("\n")
+
+	if len(report.Errors) > 0 {
+		writeTextHeading(&sb, "Errors", "-")
+		rows := [][]string{{"KEY", "ERROR"}}
+		for _, task := range report.Errors {
+			rows = append(rows, []string{task.Key, task.Description})
+		}
+		writeTextTable(&sb, "", rows)
+		sb.WriteString("\n")
+	}
+
+	writeTextHeading(&sb, "Task Details", "-")
+	for i, task := range report.Tasks {
+		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, task.Key))
+
+		fields := [][]string{}
+		addField := func(name, value string) {
+			fields = append(fields, []string{name + ":", value})
+		}
+		if task.Link != "" {
+			addField("Link", task.Link)
+		}
+		addField("Status", task.Status)
+		addField("Type", task.Type)
+		addField("Project", task.Project)
+		addField("Priority", task.Priority)
+		if task.Resolution != "" {
+			addField("Resolution", task.Resolution)
+		}
+		if task.ReferencedAs != "" {
+			addField("Referenced As", task.ReferencedAs)
+		}
+		if task.RequestedKey != "" {
+			addField("Requested As", task.RequestedKey+" (moved)")
+		}
+		if task.Sprints != "" {
+			addField("Sprints", task.Sprints)
+		}
+		addField("Assignee", task.Assignee)
+		addField("Reporter", task.Reporter)
+		addField("Created", task.Created)
+		addField("Updated", task.Updated)
+		if task.Resolved != "" {
+			addField("Resolved", task.Resolved)
+		}
+		if len(task.Links) > 0 {
+			addField("Linked Issues", strings.Join(task.Links, ", "))
+		}
+		writeTextTable(&sb, "   ", fields)
+
+		if task.Description != "" {
+			sb.WriteString("\n   Description:\n")
+			for _, line := range strings.Split(task.Description, "\n") {
+				sb.WriteString(strings.TrimRight("     "+line, " ") + "\n")
+			}
+		}
+
+		if len(task.Transitions) > 0 {
+			sb.WriteString("\n   Transition History:\n")
+			rows := [][]string{{"FROM STATUS", "TO STATUS", "AUTHOR", "DATE"}}
+			for _, transition := range task.Transitions {
+				rows = append(rows, []string{transition.FromStatus, transition.ToStatus, transition.Author, transition.Date})
+			}
+			writeTextTable(&sb, "     ", rows)
+		}
+		sb.WriteString("\n")
+	}
+
+	writeTextHeading(&sb, "Status Distribution", "-")
+	rows = [][]string{{"STATUS", "COUNT"}}
+	for _, statusCount := range report.StatusCounts {
+		rows = append(rows, []string{statusCount.Status, fmt.Sprint(statusCount.Count)})
+	}
+	writeTextTable(&sb, "", rows)
+
+	return sb.String()
+}
+
+// writeTextHeading writes a title underlined with the given character
+func writeTextHeading(sb *strings.Builder, title, underline string) {
+	sb.WriteString(title + "\n")
+	sb.WriteString(strings.Repeat(underline, len(title)) + "\n\n")
+}
+
+// writeTextTable writes rows as columns aligned to their widest cell, each line prefixed with indent.
+// Line breaks and tabs inside a cell are flattened to spaces so a row stays on one line.
+func writeTextTable(sb *strings.Builder, indent string, rows [][]string) {
+	w := tabwriter.NewWriter(sb, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, cell := range row {
+			cells[i] = strings.NewReplacer("\r\n", " ", "\n", " ", "\t", " ").Replace(cell)
+		}
+		fmt.Fprintln(w, indent+strings.Join(cells, "\t"))
+	}
+	w.Flush()
+}
@@ -0,0 +1,58 @@
+package evidence
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderText(t *testing.T) {
+	response := TransitionCheckResponse{
+		Tasks: []JiraTransitionResult{
+			{
+				Key:         "EV-1",
+				Link:        "https://example.atlassian.net/browse/EV-1",
+				Status:      "In Progress",
+				Type:        "Bug",
+				Priority:    "High",
+				Assignee:    strPtr("Jane Doe"),
+				Reporter:    "John Smith",
+				Created:     "2024-03-04T05:06:07.000+0000",
+				Description: "First line\nSecond line",
+				Transitions: []Transition{
+					{FromStatus: "To Do", ToStatus: "In Progress", Author: "Jane Doe", TransitionTime: "2024-03-05T05:06:07.000+0000"},
+				},
+			},
+			{
+				Key:         "EV-10",
+				Status:      ErrorStatus,
+				Type:        ErrorType,
+				Description: "Error 404: Could not retrieve issue",
+			},
+		},
+	}
+
+	text := RenderText(response, MarkdownOptions{DateFormat: "2006-01-02"})
+
+	assert.True(t, strings.HasPrefix(text, "JIRA Tasks Report\n=================\n\n"))
+	assert.Contains(t, text, "Total tasks: 2\nTotal transitions: 1\nTasks with errors: 1\n")
+
+	// Columns are aligned to the widest cell
+	assert.Contains(t, text, "KEY    STATUS       TYPE   PRIORITY  ASSIGNEE    TRANSITIONS\n")
+	assert.Contains(t, text, "EV-1   In Progress  Bug    High      Jane Doe    1\n")
+	assert.Contains(t, text, "EV-10  Error        Error            Unassigned  0\n")
+	assert.Contains(t, text, "KEY    ERROR\nEV-10  Error 404: Could not retrieve issue\n")
+
+	assert.Contains(t, text, "1. EV-1\n   Link:        https://example.atlassian.net/browse/EV-1\n   Status:      In Progress\n")
+	assert.Contains(t, text, "   Resolution:  Unresolved\n")
+	assert.Contains(t, text, "   Created:     2024-03-04\n")
+	assert.Contains(t, text, "   Description:\n     First line\n     Second line\n")
+	assert.Contains(t, text, "     FROM STATUS  TO STATUS    AUTHOR    DATE\n     To Do        In Progress  Jane Doe  2024-03-05\n")
+	assert.Contains(t, text, "STATUS       COUNT\nError        1\nIn Progress  1\n")
+
+	// No markdown markup
+	assert.NotContains(t, text, "|")
+	assert.NotContains(t, text, "**")
+	assert.NotContains(t, text, "##")
+}
@@ -3,8 +3,10 @@ module jira-helper
 go 1.21
 
 require (
+	github.com/BurntSushi/toml v1.4.0
 	github.com/andygrunwald/go-jira/v2 v2.0.0-20250706111204-51c7813d292d
 	github.com/stretchr/testify v1.8.4
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -15,5 +17,4 @@ require (
 	github.com/pmezard/go-difflib v1.0.0 // indirect
 	github.com/stretchr/objx v0.5.0 // indirect
 	github.com/trivago/tgo v1.0.7 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -15,6 +15,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"jira-helper/evidence"
 )
 
 // Note: Environment variables should be set before running these tests.
@@ -82,7 +84,7 @@ func TestJIRAConnection(t *testing.T) {
 		t.Skip("Skipping: JIRA_API_TOKEN not set")
 	}
 
-	client, err := NewJiraClient()
+	client, err := evidence.NewJiraClient(evidence.JiraClientOptions{})
 	require.NoError(t, err, "Failed to create JIRA client")
 	assert.NotNil(t, client)
 }
@@ -93,7 +95,7 @@ func TestJIRAOperations(t *testing.T) {
 		t.Skip("Skipping: JIRA_API_TOKEN not set")
 	}
 
-	client, err := NewJiraClient()
+	client, err := evidence.NewJiraClient(evidence.JiraClientOptions{})
 	require.NoError(t, err, "Failed to create JIRA client")
 
 	t.Run("FetchExistingTicket", func(t *testing.T) {
@@ -159,7 +161,7 @@ func TestGitOperations(t *testing.T) {
 		t.Skip("Skipping: JIRA_API_TOKEN not set")
 	}
 
-	gitService := NewGitService()
+	gitService := evidence.NewGitService()
 
 	t.Run("CheckRepository", func(t *testing.T) {
 		err := gitService.CheckRepository()
@@ -257,7 +259,7 @@ func TestFullWorkflow(t *testing.T) {
 		OutputFile:  "test_workflow_output.json",
 	}
 
-	gitService := NewGitService()
+	gitService := evidence.NewGitService()
 
 	// Get current branch
 	branch, _, _, err := gitService.GetBranchInfo()
@@ -274,7 +276,7 @@ func TestFullWorkflow(t *testing.T) {
 	t.Logf("Extracted JIRA IDs from %s: %v", testCommit, jiraIDs)
 
 	// Fetch details for each JIRA ID
-	client, err := NewJiraClient()
+	client, err := evidence.NewJiraClient(evidence.JiraClientOptions{})
 	require.NoError(t, err, "Failed to create JIRA client")
 
 	response := client.FetchJiraDetails(jiraIDs)
@@ -283,7 +285,7 @@ func TestFullWorkflow(t *testing.T) {
 	}
 
 	// Write results
-	err = saveJiraResults(response, config)
+	err = saveJiraResults(response, config, false)
 	assert.NoError(t, err, "Failed to write output file")
 
 	// Verify file was created
@@ -324,7 +326,7 @@ func TestDirectJIRAProcessing(t *testing.T) {
 	data, err := os.ReadFile(config.OutputFile)
 	assert.NoError(t, err, "Failed to read output file")
 
-	var response TransitionCheckResponse
+	var response evidence.TransitionCheckResponse
 	err = json.Unmarshal(data, &response)
 	assert.NoError(t, err, "Failed to parse JSON")
 	assert.Len(t, response.Tasks, 2, "Should have results for 2 tickets")
@@ -389,7 +391,7 @@ func TestCLIOperations(t *testing.T) {
 		data, err := os.ReadFile("transformed_jira_data.json")
 		require.NoError(t, err, "Failed to read output file")
 
-		var response TransitionCheckResponse
+		var response evidence.TransitionCheckResponse
 		err = json.Unmarshal(data, &response)
 		require.NoError(t, err, "Output file should contain valid JSON")
 
@@ -509,7 +511,7 @@ func TestWithControlledGitRepo(t *testing.T) {
 			data, err := os.ReadFile("transformed_jira_data.json")
 			assert.NoError(t, err)
 
-			var response TransitionCheckResponse
+			var response evidence.TransitionCheckResponse
 			err = json.Unmarshal(data, &response)
 			assert.NoError(t, err, "Output should be valid JSON")
 			assert.Len(t, response.Tasks, 1)
@@ -531,7 +533,7 @@ func TestPerformance(t *testing.T) {
 		t.Skip("Skipping performance tests (set TEST_PERFORMANCE=true to enable)")
 	}
 
-	client, err := NewJiraClient()
+	client, err := evidence.NewJiraClient(evidence.JiraClientOptions{})
 	require.NoError(t, err)
 
 	testJiraID := os.Getenv("TEST_EXISTING_JIRA_ID")
@@ -574,7 +576,7 @@ func TestPerformance(t *testing.T) {
 	})
 
 	t.Run("LargeCommitRangeExtraction", func(t *testing.T) {
-		gitService := NewGitService()
+		gitService := evidence.NewGitService()
 
 		// Get current branch
 		branch, _, _, err := gitService.GetBranchInfo()
@@ -1,13 +1,33 @@
 package main
 
 import (
-	"fmt"
+	"errors"
 	"os"
+
+	"jira-helper/evidence"
+)
+
+// version is the tool version recorded in the evidence JSON, set at build time with
+// -ldflags "-X main.version=..." (see the Makefile)
+var version = "dev"
+
+// ErrNoJiraIDs is returned by extract-only mode with --fail-on-empty when no JIRA IDs were found
+var ErrNoJiraIDs = errors.New("no JIRA IDs found")
+
+// ErrInterrupted is returned when SIGINT/SIGTERM stopped fetching; the partial output has been saved
+var ErrInterrupted = errors.New("interrupted")
+
+// Process exit codes
+const (
+	ExitCodeError       = 1
+	ExitCodeNoResults   = 2   // --fail-on-empty and no JIRA IDs were found
+	ExitCodeInterrupted = 130 // Stopped by SIGINT/SIGTERM, as shells report for Ctrl-C
 )
 
 func main() {
 	// Parse command line flags
 	flags, args := ParseFlags()
+	evidence.ConfigureColor(flags.NoColor)
 
 	// Handle help flags
 	if flags.Help || flags.HelpLong {
@@ -18,14 +38,33 @@ func main() {
 	// Load configuration
 	config, err := LoadConfig(flags, args)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		evidence.PrintError("Error loading configuration: %v", err)
 		DisplayUsage()
-		os.Exit(1)
+		os.Exit(ExitCodeError)
+	}
+	if config.GitPath != "" {
+		if err := evidence.SetGitBinary(config.GitPath); err != nil {
+			evidence.PrintError("Error: %v", err)
+			os.Exit(ExitCodeError)
+		}
 	}
 
+	config.Stats = evidence.NewRunStats()
+
 	// Determine and execute the appropriate mode
-	if err := determineExecutionMode(flags, args, config); err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		os.Exit(1)
+	err = determineExecutionMode(flags, args, config)
+	if !config.Quiet {
+		evidence.PrintRunSummary(os.Stderr, config.Stats, config.LogFormat)
+	}
+	if err != nil {
+		if errors.Is(err, ErrNoJiraIDs) {
+			os.Exit(ExitCodeNoResults)
+		}
+		if errors.Is(err, ErrInterrupted) {
+			evidence.PrintWarning("Partial output saved to %s", config.OutputFile)
+			os.Exit(ExitCodeInterrupted)
+		}
+		evidence.PrintError("Error: %v", err)
+		os.Exit(ExitCodeError)
 	}
 }
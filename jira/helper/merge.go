@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"jira-helper/evidence"
+)
+
+// Strategies for resolving tasks with the same key when merging evidence files
+const (
+	MergeKeepLast  = "last"
+	MergeKeepFirst = "first"
+)
+
+// MergeJSONFiles merges multiple evidence JSON files into a single output file
+func MergeJSONFiles(inputFiles []string, outputFile string, strategy string) error {
+	responses := make([]evidence.TransitionCheckResponse, 0, len(inputFiles))
+	for _, inputFile := range inputFiles {
+		response, err := evidence.LoadResponseFile(inputFile)
+		if err != nil {
+			return fmt.Errorf("%s: %w", inputFile, err)
+		}
+		fmt.Printf("Loaded %d tasks from %s\n", len(response.Tasks), inputFile)
+		responses = append(responses, response)
+	}
+
+	merged := mergeResponses(responses, strategy)
+	merged.Meta = newResponseMeta(nil)
+
+	jsonBytes, err := json.MarshalIndent(merged, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error marshaling JSON: %v", err)
+	}
+
+	if err := writeToFile(outputFile, jsonBytes); err != nil {
+		return fmt.Errorf("error writing to file: %v", err)
+	}
+
+	fmt.Printf("Merged %d tasks into: %s\n", len(merged.Tasks), outputFile)
+	return nil
+}
+
+// mergeResponses concatenates tasks from all responses and de-duplicates them by key.
+// Tasks keep the position of the first occurrence of their key; which copy wins is
+// decided by the strategy. Duplicates with conflicting statuses produce a warning.
+func mergeResponses(responses []evidence.TransitionCheckResponse, strategy string) evidence.TransitionCheckResponse {
+	merged := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{},
+	}
+	indexByKey := make(map[string]int)
+
+	for _, response := range responses {
+		for _, task := range response.Tasks {
+			index, exists := indexByKey[task.Key]
+			if !exists {
+				indexByKey[task.Key] = len(merged.Tasks)
+				merged.Tasks = append(merged.Tasks, task)
+				continue
+			}
+
+			existing := merged.Tasks[index]
+			if existing.Status != task.Status {
+				evidence.PrintWarning("Duplicate key %s has conflicting statuses: '%s' and '%s'",
+					task.Key, existing.Status, task.Status)
+			}
+
+			if strategy != MergeKeepFirst {
+				merged.Tasks[index] = task
+			}
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"jira-helper/evidence"
+)
+
+func TestMergeResponses(t *testing.T) {
+	first := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1", Status: "In Progress"},
+			{Key: "EV-2", Status: "Done"},
+		},
+	}
+	second := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-3", Status: "To Do"},
+			{Key: "EV-1", Status: "Done"},
+		},
+	}
+
+	tests := []struct {
+		name             string
+		strategy         string
+		expectedKeys     []string
+		expectedStatuses []string
+	}{
+		{
+			name:             "Last write wins",
+			strategy:         MergeKeepLast,
+			expectedKeys:     []string{"EV-1", "EV-2", "EV-3"},
+			expectedStatuses: []string{"Done", "Done", "To Do"},
+		},
+		{
+			name:             "First write wins",
+			strategy:         MergeKeepFirst,
+			expectedKeys:     []string{"EV-1", "EV-2", "EV-3"},
+			expectedStatuses: []string{"In Progress", "Done", "To Do"},
+		},
+		{
+			name:             "Empty strategy defaults to last",
+			strategy:         "",
+			expectedKeys:     []string{"EV-1", "EV-2", "EV-3"},
+			expectedStatuses: []string{"Done", "Done", "To Do"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Capture stderr for conflict warnings
+			oldStderr := os.Stderr
+			r, w, _ := os.Pipe()
+			os.Stderr = w
+
+			merged := mergeResponses([]evidence.TransitionCheckResponse{first, second}, tt.strategy)
+
+			w.Close()
+			os.Stderr = oldStderr
+			buf := make([]byte, 1024)
+			n, _ := r.Read(buf)
+			stderrOutput := string(buf[:n])
+
+			var keys, statuses []string
+			for _, task := range merged.Tasks {
+				keys = append(keys, task.Key)
+				statuses = append(statuses, task.Status)
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+			assert.Equal(t, tt.expectedStatuses, statuses)
+			assert.Contains(t, stderrOutput, "Duplicate key EV-1 has conflicting statuses")
+		})
+	}
+}
+
+func TestMergeResponsesNoConflictWarning(t *testing.T) {
+	responses := []evidence.TransitionCheckResponse{
+		{Tasks: []evidence.JiraTransitionResult{{Key: "EV-1", Status: "Done"}}},
+		{Tasks: []evidence.JiraTransitionResult{{Key: "EV-1", Status: "Done"}}},
+		{Tasks: nil},
+	}
+
+	oldStderr := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	merged := mergeResponses(responses, MergeKeepLast)
+
+	w.Close()
+	os.Stderr = oldStderr
+	buf := make([]byte, 1024)
+	n, _ := r.Read(buf)
+
+	assert.Len(t, merged.Tasks, 1)
+	assert.Empty(t, string(buf[:n]))
+}
+
+func TestMergeJSONFiles(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "merge-test")
+	require.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileA := filepath.Join(tempDir, "a.json")
+	fileB := filepath.Join(tempDir, "b.json")
+	invalid := filepath.Join(tempDir, "invalid.json")
+	require.NoError(t, os.WriteFile(fileA, []byte(`{"tasks": [{"key": "EV-1", "status": "Done"}]}`), 0644))
+	require.NoError(t, os.WriteFile(fileB, []byte(`{"tasks": [{"key": "OPS-2", "status": "Open"}]}`), 0644))
+	require.NoError(t, os.WriteFile(invalid, []byte(`not json`), 0644))
+
+	t.Run("Merges files into output", func(t *testing.T) {
+		output := filepath.Join(tempDir, "out", "combined.json")
+
+		err := MergeJSONFiles([]string{fileA, fileB}, output, MergeKeepLast)
+		require.NoError(t, err)
+
+		merged, err := evidence.LoadResponseFile(output)
+		require.NoError(t, err)
+		assert.Len(t, merged.Tasks, 2)
+		assert.Equal(t, "EV-1", merged.Tasks[0].Key)
+		assert.Equal(t, "OPS-2", merged.Tasks[1].Key)
+	})
+
+	t.Run("Invalid input file", func(t *testing.T) {
+		err := MergeJSONFiles([]string{fileA, invalid}, filepath.Join(tempDir, "bad.json"), MergeKeepLast)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid.json")
+		assert.Contains(t, err.Error(), "error parsing JSON")
+	})
+
+	t.Run("Missing input file", func(t *testing.T) {
+		err := MergeJSONFiles([]string{fileA, filepath.Join(tempDir, "missing.json")}, filepath.Join(tempDir, "bad.json"), MergeKeepLast)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "error reading JSON file")
+	})
+}
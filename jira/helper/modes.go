@@ -1,24 +1,32 @@
 package main
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"jira-helper/evidence"
 )
 
 // runExtractOnlyMode runs the tool in extract-only mode
 func runExtractOnlyMode(config *AppConfig) error {
-	git := NewGitService()
+	git := evidence.NewGitServiceWithOptions(newExtractOptions(config)).WithStats(config.Stats).WithVerbose(config.Verbose)
 
 	fmt.Println("=== JIRA ID Extraction (Extract Only Mode) ===")
-	if config.SingleCommit {
-		fmt.Printf("Commit: %s\n", config.StartCommit)
-	} else {
-		fmt.Printf("Start Commit: %s\n", config.StartCommit)
-	}
-	fmt.Printf("JIRA ID Regex: %s\n", config.JIRAIDRegex)
+	printCommitSelection(config)
+	printJiraIDRegex(config)
 	fmt.Println("")
 
 	// Get branch info
@@ -29,21 +37,25 @@ func runExtractOnlyMode(config *AppConfig) error {
 
 	fmt.Printf("Branch: %s\n", branchName)
 	fmt.Printf("Latest Commit: %s\n", commitHash)
+	git, config = applyBranchRule(git, config, branchName)
 
 	// Validate HEAD
 	if err := git.ValidateHEAD(); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		evidence.PrintError("%v", err)
 		return nil // Exit gracefully
 	}
 
 	// Extract JIRA IDs
-	jiraIDs, err := git.ExtractJiraIDs(config.StartCommit, config.JIRAIDRegex, currentJiraID, config.SingleCommit)
+	jiraIDs, err := extractJiraIDsForConfig(git, config, currentJiraID)
 	if err != nil {
 		return fmt.Errorf("failed to extract JIRA IDs: %w", err)
 	}
 
 	if len(jiraIDs) == 0 {
 		fmt.Println("No JIRA IDs found")
+		if config.FailOnEmpty {
+			return ErrNoJiraIDs
+		}
 		return nil
 	}
 
@@ -59,7 +71,7 @@ func runLegacyExtractFromGit(args []string) error {
 		return fmt.Errorf("insufficient arguments")
 	}
 
-	git := NewGitService()
+	git := evidence.NewGitService()
 	startCommit := args[0]
 	regex := args[1]
 
@@ -75,13 +87,13 @@ func runLegacyExtractFromGit(args []string) error {
 
 	// Validate HEAD
 	if err := git.ValidateHEAD(); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		evidence.PrintError("%v", err)
 		return nil // Exit gracefully
 	}
 
 	// Validate commit
 	if err := git.ValidateCommit(startCommit); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		evidence.PrintError("%v", err)
 		return nil // Exit gracefully
 	}
 
@@ -103,20 +115,16 @@ func runLegacyExtractFromGit(args []string) error {
 
 // runFullMode runs the complete JIRA evidence gathering process
 func runFullMode(config *AppConfig) error {
-	git := NewGitService()
+	git := evidence.NewGitServiceWithOptions(newExtractOptions(config)).WithStats(config.Stats).WithVerbose(config.Verbose)
 
 	fmt.Println("=== JIRA Details Fetching Process ===")
-	if config.SingleCommit {
-		fmt.Printf("Commit: %s\n", config.StartCommit)
-	} else {
-		fmt.Printf("Start Commit: %s\n", config.StartCommit)
-	}
-	fmt.Printf("JIRA ID Regex: %s\n", config.JIRAIDRegex)
+	printCommitSelection(config)
+	printJiraIDRegex(config)
 	fmt.Printf("Output File: %s\n", config.OutputFile)
 	fmt.Println("")
 
 	// Step 1: Extract JIRA IDs from git commits
-	if config.SingleCommit {
+	if config.SingleCommit && config.FromRef == "" && config.CommitsFile == "" {
 		fmt.Println("Step 1: Extracting JIRA IDs from commit...")
 	} else {
 		fmt.Println("Step 1: Extracting JIRA IDs from git commits...")
@@ -131,15 +139,34 @@ func runFullMode(config *AppConfig) error {
 	// Display branch information
 	fmt.Printf("Branch: %s\n", branchName)
 	fmt.Printf("Latest Commit: %s\n", commitHash)
+	git, config = applyBranchRule(git, config, branchName)
+
+	// The remote is only provenance, so failing to read it doesn't stop the run
+	remoteURL, err := git.GetRemoteURL()
+	if err != nil {
+		evidence.PrintWarning("Could not read the origin remote URL: %v", err)
+	}
+	config.Source = &evidence.SourceInfo{Repository: remoteURL, Branch: branchName, Commit: commitHash}
 
 	// Validate HEAD
 	if err := git.ValidateHEAD(); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ %v\n", err)
+		evidence.PrintError("%v", err)
 		return nil // Exit gracefully
 	}
 
+	if config.IfNewer {
+		upToDate, err := outputUpToDate(git, config.OutputFile)
+		if err != nil {
+			return fmt.Errorf("error checking whether the output file is up to date: %v", err)
+		}
+		if upToDate {
+			fmt.Printf("Output file %s is newer than the HEAD commit, skipping the fetch (--if-newer)\n", config.OutputFile)
+			return nil
+		}
+	}
+
 	// Extract JIRA IDs
-	jiraIDs, err := git.ExtractJiraIDs(config.StartCommit, config.JIRAIDRegex, currentJiraID, config.SingleCommit)
+	jiraIDs, err := extractJiraIDsForConfig(git, config, currentJiraID)
 	if err != nil {
 		return fmt.Errorf("error extracting JIRA IDs: %v", err)
 	}
@@ -157,70 +184,627 @@ func runFullMode(config *AppConfig) error {
 	fmt.Println("Step 2: Fetching JIRA details...")
 
 	// Create JIRA client
-	jiraClient, err := NewJiraClient()
+	jiraClient, err := evidence.NewJiraClient(newJiraClientOptions(config))
 	if err != nil {
 		return fmt.Errorf("error creating JIRA client: %v", err)
 	}
 
 	// Process JIRA IDs and get results
-	response := jiraClient.FetchJiraDetails(config.JIRAIDs)
+	response, err := fetchJiraDetails(jiraClient, config.JIRAIDs, config)
+	if err != nil {
+		return err
+	}
+
+	if config.WithCommitMeta {
+		revisionRange, err := commitRangeForConfig(git, config)
+		if err != nil {
+			return err
+		}
+		refs, err := git.ExtractCommitRefs(revisionRange, config.JIRAIDRegex)
+		if err != nil {
+			return fmt.Errorf("error reading commit metadata: %v", err)
+		}
+		response = attachCommitRefs(response, refs)
+	}
 
 	// Step 3: Write results to file
 	fmt.Println("")
 	fmt.Println("Step 3: Writing results...")
 
-	if err := saveJiraResults(response, config); err != nil {
+	if err := saveJiraResults(response, config, false); err != nil {
 		return err
 	}
 
 	fmt.Println("")
-	fmt.Println("=== Process completed successfully ===")
+	evidence.PrintSuccess("=== Process completed successfully ===")
 	return nil
 }
 
+// applyBranchRule switches to the regexes of the branch rule matching branchName, if any, returning
+// the configuration and a git service with the matching extraction options. The rule applies to
+// this run only: the configuration is copied, so a watch run on another branch starts afresh.
+func applyBranchRule(git *evidence.GitService, config *AppConfig, branchName string) (*evidence.GitService, *AppConfig) {
+	branchConfig, rule := config.forBranch(branchName)
+	if rule == nil {
+		return git, config
+	}
+
+	fmt.Printf("Branch Rule: %s\n", rule.Branch)
+	printJiraIDRegex(branchConfig)
+	return evidence.NewGitServiceWithOptions(newExtractOptions(branchConfig)).WithStats(config.Stats).WithVerbose(config.Verbose), branchConfig
+}
+
+// outputUpToDate reports whether --if-newer can skip the run: the output file exists and was last
+// modified strictly after HEAD's committer date. Commit dates have a resolution of one second.
+func outputUpToDate(git *evidence.GitService, outputFile string) (bool, error) {
+	info, err := os.Stat(outputFile)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	commitTime, err := git.GetCommitTime("HEAD")
+	if err != nil {
+		return false, err
+	}
+	return info.ModTime().After(commitTime), nil
+}
+
+// printCommitSelection prints which commits will be scanned for JIRA IDs
+func printCommitSelection(config *AppConfig) {
+	switch {
+	case config.FromRef != "":
+		fmt.Printf("Range: %s..%s\n", config.FromRef, config.ToRef)
+	case config.BaseBranch != "":
+		fmt.Printf("Base Branch: %s (commits on HEAD since the merge-base)\n", config.BaseBranch)
+	case config.CommitsFile != "":
+		fmt.Printf("Commits File: %s (only the listed commits)\n", config.CommitsFile)
+	case config.SingleCommit:
+		fmt.Printf("Commit: %s\n", config.StartCommit)
+	default:
+		fmt.Printf("Start Commit: %s\n", config.StartCommit)
+	}
+}
+
+// extractJiraIDsForConfig extracts JIRA IDs from the commits selected by the configuration
+func extractJiraIDsForConfig(git *evidence.GitService, config *AppConfig, currentJiraID string) ([]string, error) {
+	if config.FromRef != "" {
+		return git.ExtractJiraIDsBetween(config.FromRef, config.ToRef, config.JIRAIDRegex)
+	}
+	if config.BaseBranch != "" {
+		// Resolved on every run so --watch follows rebases onto a newer base
+		mergeBase, err := git.GetMergeBase(config.BaseBranch, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Merge Base: %s\n", mergeBase)
+		return git.ExtractJiraIDs(mergeBase, config.JIRAIDRegex, currentJiraID, false)
+	}
+	if config.CommitsFile != "" {
+		// Re-read on every run so --watch picks up edits to the list
+		commits, err := readCommitsFile(config.CommitsFile)
+		if err != nil {
+			return nil, err
+		}
+		return git.ExtractJiraIDsFromCommits(commits, config.JIRAIDRegex)
+	}
+	ids, err := git.ExtractJiraIDs(config.StartCommit, config.JIRAIDRegex, currentJiraID, config.SingleCommit)
+	if err != nil || len(ids) > 0 || !config.SingleCommit || !config.FallbackToRange {
+		return ids, err
+	}
+	fmt.Printf("No JIRA IDs in commit %s, falling back to the range %s..HEAD\n", config.StartCommit, config.StartCommit)
+	return git.ExtractJiraIDs(config.StartCommit, config.JIRAIDRegex, currentJiraID, false)
+}
+
+// commitRangeForConfig returns the git revision range scanned in range mode, for --with-commit-meta
+func commitRangeForConfig(git *evidence.GitService, config *AppConfig) (string, error) {
+	if config.FromRef != "" {
+		return config.FromRef + ".." + config.ToRef, nil
+	}
+	if config.BaseBranch != "" {
+		mergeBase, err := git.GetMergeBase(config.BaseBranch, "HEAD")
+		if err != nil {
+			return "", err
+		}
+		return mergeBase + "..HEAD", nil
+	}
+	return config.StartCommit + "..HEAD", nil
+}
+
+// attachCommitRefs sets each task's commits from refs, keyed by the ID the commits referenced it
+// by (ReferencedAs for aliased keys, RequestedKey for moved tickets)
+func attachCommitRefs(response evidence.TransitionCheckResponse, refs map[string][]evidence.CommitRef) evidence.TransitionCheckResponse {
+	tasks := make([]evidence.JiraTransitionResult, len(response.Tasks))
+	for i, task := range response.Tasks {
+		task.Commits = refs[getOrDefault(task.ReferencedAs, task.RequestedKey, task.Key)]
+		tasks[i] = task
+	}
+	response.Tasks = tasks
+	return response
+}
+
+// printJiraIDRegex prints the primary JIRA ID regex and any project regexes from the config file
+func printJiraIDRegex(config *AppConfig) {
+	fmt.Printf("JIRA ID Regex: %s\n", config.JIRAIDRegex)
+	names := make([]string, 0, len(config.ProjectRegexes))
+	for name := range config.ProjectRegexes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("Project Regex (%s): %s\n", name, config.ProjectRegexes[name])
+	}
+}
+
+// newExtractOptions builds the git extraction options from the configuration
+func newExtractOptions(config *AppConfig) evidence.ExtractOptions {
+	return evidence.ExtractOptions{
+		Path:             config.Path,
+		FirstOnly:        config.FirstOnly,
+		IgnoreCase:       config.IgnoreCase,
+		NoMerges:         config.NoMerges,
+		IncludeNotes:     config.IncludeNotes,
+		Strict:           config.Strict,
+		MaxMessageLength: config.MaxMessageLength,
+		UseReflog:        config.UseReflog,
+		IgnoreKeys:       config.IgnoreKeys,
+		ProjectRegexes:   config.ProjectRegexes,
+		ContinueOnError:  config.ContinueOnError,
+	}
+}
+
+// newMarkdownOptions builds the markdown report options from the configuration
+func newMarkdownOptions(config *AppConfig) evidence.MarkdownOptions {
+	return evidence.MarkdownOptions{
+		DateFormat: config.DateFormat,
+		SortBy:     config.SortBy,
+		GroupBy:    config.GroupBy,
+		TOC:        config.TOC,
+		Location:   config.Location,
+		Anonymize:  config.Anonymize,
+	}
+}
+
+// newJiraClientOptions builds the JIRA client options from the configuration
+func newJiraClientOptions(config *AppConfig) evidence.JiraClientOptions {
+	return evidence.JiraClientOptions{
+		IncludeSprints:              config.IncludeSprints,
+		IncludeUserIDs:              config.IncludeUserIDs,
+		IncludeLinks:                config.IncludeLinks,
+		IncludeSubtasks:             config.IncludeSubtasks,
+		CollapseRepeatedTransitions: config.CollapseTransitions,
+		KeyAliases:                  config.KeyAliases,
+		Token:                       config.JIRAToken,
+		URL:                         config.JIRAURL,
+		Username:                    config.JIRAUsername,
+		Stats:                       config.Stats,
+		Verbose:                     config.Verbose,
+		ProxyURL:                    config.ProxyURL,
+		InsecureSkipVerify:          config.InsecureSkipVerify,
+		CACertFile:                  config.CACertFile,
+		UserAgent:                   getOrDefault(config.UserAgent, evidence.DefaultUserAgent(version)),
+		LinkTemplate:                config.LinkTemplate,
+		MaxIdleConns:                config.MaxIdleConns,
+		IdleConnTimeout:             config.IdleConnTimeout,
+		DisableKeepAlives:           config.NoKeepAlives,
+		Workers:                     config.Workers,
+		DumpRawDir:                  config.DumpRawDir,
+		Headers:                     config.Headers,
+		MaxDescription:              config.MaxDescription,
+	}
+}
+
 // processDirectJiraIDs handles direct JIRA ID processing (no git operations)
 func processDirectJiraIDs(config *AppConfig) error {
 	fmt.Printf("Processing JIRA IDs: %s\n", strings.Join(config.JIRAIDs, ", "))
 
 	// Create a new Jira client
-	jiraClient, err := NewJiraClient()
+	jiraClient, err := evidence.NewJiraClient(newJiraClientOptions(config))
 	if err != nil {
 		return fmt.Errorf("error creating JIRA client: %v", err)
 	}
 
 	// Get response
-	response := jiraClient.FetchJiraDetails(config.JIRAIDs)
+	response, err := fetchJiraDetails(jiraClient, config.JIRAIDs, config)
+	if err != nil {
+		return err
+	}
 
 	// Save results to file using the same method as other modes
-	if err := saveJiraResults(response, config); err != nil {
+	if err := saveJiraResults(response, config, false); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// saveJiraResults saves JIRA results to JSON
-func saveJiraResults(response TransitionCheckResponse, config *AppConfig) error {
-	// Save JSON
-	jsonBytes, err := json.MarshalIndent(response, "", "  ")
+// runJQLMode fetches the details of every issue matching the configured JQL query
+func runJQLMode(config *AppConfig) error {
+	fmt.Println("=== JQL Mode ===")
+	fmt.Printf("JQL: %s\n", config.JQL)
+	fmt.Println("")
+
+	jiraClient, err := evidence.NewJiraClient(newJiraClientOptions(config))
+	if err != nil {
+		return fmt.Errorf("error creating JIRA client: %v", err)
+	}
+
+	jiraIDs, err := jiraClient.SearchJiraIDs(config.JQL)
+	if err != nil {
+		return err
+	}
+
+	if len(jiraIDs) == 0 {
+		evidence.PrintWarning("No issues matched the JQL query")
+	} else {
+		fmt.Printf("Processing JIRA IDs: %s\n", strings.Join(jiraIDs, ", "))
+	}
+
+	response, err := fetchJiraDetails(jiraClient, jiraIDs, config)
+	if err != nil {
+		return err
+	}
+	return saveJiraResults(response, config, false)
+}
+
+// runScanFileMode fetches the details of every JIRA ID referenced in the configured text file
+func runScanFileMode(config *AppConfig) error {
+	fmt.Println("=== Scan File Mode ===")
+	fmt.Printf("Scan File: %s\n", config.ScanFile)
+	printJiraIDRegex(config)
+	fmt.Println("")
+
+	data, err := os.ReadFile(config.ScanFile)
+	if err != nil {
+		return fmt.Errorf("failed to read scan file: %w", err)
+	}
+
+	jiraIDs, err := evidence.ExtractIDsFromText(string(data), config.JIRAIDRegex, newExtractOptions(config))
+	if err != nil {
+		return fmt.Errorf("error extracting JIRA IDs: %v", err)
+	}
+
+	if len(jiraIDs) == 0 {
+		fmt.Println("No JIRA IDs found in scan file")
+		return nil
+	}
+
+	fmt.Printf("Processing JIRA IDs: %s\n", strings.Join(jiraIDs, ", "))
+	config.JIRAIDs = jiraIDs
+
+	jiraClient, err := evidence.NewJiraClient(newJiraClientOptions(config))
+	if err != nil {
+		return fmt.Errorf("error creating JIRA client: %v", err)
+	}
+
+	response, err := fetchJiraDetails(jiraClient, jiraIDs, config)
+	if err != nil {
+		return err
+	}
+	return saveJiraResults(response, config, false)
+}
+
+// runListStatusesMode prints the statuses of a project's workflows, to help build
+// --include-status/--exclude-status lists
+func runListStatusesMode(config *AppConfig) error {
+	fmt.Println("=== Project Statuses ===")
+	fmt.Printf("Project: %s\n", config.ListStatuses)
+	fmt.Println("")
+
+	jiraClient, err := evidence.NewJiraClient(newJiraClientOptions(config))
+	if err != nil {
+		return fmt.Errorf("error creating JIRA client: %v", err)
+	}
+
+	statuses, err := jiraClient.ListProjectStatuses(config.ListStatuses)
+	if err != nil {
+		return err
+	}
+
+	if len(statuses) == 0 {
+		evidence.PrintWarning("Project %s has no statuses", config.ListStatuses)
+		return nil
+	}
+	printProjectStatuses(statuses)
+	return nil
+}
+
+// printProjectStatuses prints one status per line with its category and the issue types using it
+func printProjectStatuses(statuses []evidence.ProjectStatus) {
+	for _, status := range statuses {
+		fmt.Printf("  %s", status.Name)
+		if status.Category != "" {
+			fmt.Printf(" [%s]", status.Category)
+		}
+		fmt.Printf(" - %s\n", strings.Join(status.IssueTypes, ", "))
+	}
+}
+
+// fetchJiraDetails fetches the details of the JIRA IDs, stopping early on SIGINT/SIGTERM.
+// When interrupted, the tasks fetched so far are saved to the output file and ErrInterrupted is returned.
+func fetchJiraDetails(jiraClient *evidence.JiraClient, jiraIDs []string, config *AppConfig) (evidence.TransitionCheckResponse, error) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return fetchJiraDetailsUntil(ctx, jiraClient, jiraIDs, config)
+}
+
+// fetchJiraDetailsUntil fetches the details of the JIRA IDs until ctx is cancelled, saving partial output if it is
+func fetchJiraDetailsUntil(ctx context.Context, jiraClient *evidence.JiraClient, jiraIDs []string, config *AppConfig) (evidence.TransitionCheckResponse, error) {
+	response, err := jiraClient.FetchJiraDetailsContext(ctx, jiraIDs)
+	if err == nil {
+		return response, nil
+	}
+
+	fmt.Println("")
+	evidence.PrintWarning("Interrupted after fetching %d of %d tickets, saving partial output", len(response.Tasks), len(jiraIDs))
+	if err := saveJiraResults(response, config, true); err != nil {
+		return response, err
+	}
+	return response, ErrInterrupted
+}
+
+// saveJiraResults saves JIRA results to JSON.
+// With --append, the results are merged into an existing output file, newly fetched tasks replacing
+// older copies of the same key. Partial results of an interrupted run are marked as such in the meta
+// block, and neither checksummed nor POSTed, so they can't pass for complete evidence.
+func saveJiraResults(response evidence.TransitionCheckResponse, config *AppConfig, partial bool) error {
+	response = filterByStatus(response, config)
+
+	if config.Append {
+		existing, err := loadExistingResults(config.OutputFile)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			fmt.Printf("Appending to %s (%d existing tasks)\n", config.OutputFile, len(existing.Tasks))
+			response = mergeResponses([]evidence.TransitionCheckResponse{*existing, response}, MergeKeepLast)
+		}
+	}
+
+	if config.Anonymize {
+		response = evidence.Anonymize(response)
+	}
+	response.Meta = newResponseMeta(config.Source)
+	response.Meta.Partial = partial
+
+	jsonBytes, err := marshalResults(response, config)
 	if err != nil {
 		return fmt.Errorf("error marshaling JSON: %v", err)
 	}
 
-	if err := writeToFile(config.OutputFile, jsonBytes); err != nil {
-		return fmt.Errorf("error writing to file: %v", err)
+	// Save JSON
+	if config.writesFormat(FormatJSON) {
+		if err := writeToFile(config.OutputFile, jsonBytes); err != nil {
+			return fmt.Errorf("error writing to file: %v", err)
+		}
+
+		fmt.Printf("JIRA data saved to: %s\n", config.OutputFile)
+
+		if config.Checksum && !partial {
+			if err := writeChecksum(config.OutputFile, jsonBytes); err != nil {
+				return err
+			}
+		}
 	}
 
-	fmt.Printf("JIRA data saved to: %s\n", config.OutputFile)
+	// Reports are rendered from the same response, so they always match the JSON
+	if config.writesFormat(FormatMarkdown) {
+		markdownFile := outputFileWithExtension(config.OutputFile, ".md")
+		markdown := evidence.RenderMarkdown(response, newMarkdownOptions(config))
+		if err := writeToFile(markdownFile, []byte(markdown)); err != nil {
+			return fmt.Errorf("error writing markdown file: %v", err)
+		}
+		fmt.Printf("Markdown report saved to: %s\n", markdownFile)
+	}
+
+	if config.writesFormat(FormatHTML) {
+		htmlFile := outputFileWithExtension(config.OutputFile, ".html")
+		html, err := evidence.RenderHTML(response, newMarkdownOptions(config))
+		if err != nil {
+			return fmt.Errorf("error rendering HTML report: %v", err)
+		}
+		if err := writeToFile(htmlFile, []byte(html)); err != nil {
+			return fmt.Errorf("error writing HTML file: %v", err)
+		}
+		fmt.Printf("HTML report saved to: %s\n", htmlFile)
+	}
+
+	if config.writesFormat(FormatText) {
+		textFile := outputFileWithExtension(config.OutputFile, ".txt")
+		text := evidence.RenderText(response, newMarkdownOptions(config))
+		if err := writeToFile(textFile, []byte(text)); err != nil {
+			return fmt.Errorf("error writing text file: %v", err)
+		}
+		fmt.Printf("Text report saved to: %s\n", textFile)
+	}
+
+	if config.writesFormat(FormatYAML) {
+		yamlFile := outputFileWithExtension(config.OutputFile, ".yaml")
+		yamlBytes, err := jsonToYAML(jsonBytes)
+		if err != nil {
+			return fmt.Errorf("error converting results to YAML: %v", err)
+		}
+		if err := writeToFile(yamlFile, yamlBytes); err != nil {
+			return fmt.Errorf("error writing YAML file: %v", err)
+		}
+		fmt.Printf("YAML data saved to: %s\n", yamlFile)
+	}
+
+	if config.TransitionsCSV != "" {
+		csvBytes, err := evidence.RenderTransitionsCSV(response)
+		if err != nil {
+			return fmt.Errorf("error rendering transitions CSV: %v", err)
+		}
+		if err := writeToFile(config.TransitionsCSV, csvBytes); err != nil {
+			return fmt.Errorf("error writing transitions CSV: %v", err)
+		}
+		fmt.Printf("Transitions CSV saved to: %s\n", config.TransitionsCSV)
+	}
+
+	if config.PostURL != "" && partial {
+		evidence.PrintWarning("Not posting partial output to %s", config.PostURL)
+	} else if config.PostURL != "" {
+		if err := postResults(config, jsonBytes); err != nil {
+			return err
+		}
+		fmt.Printf("JIRA data posted to: %s\n", config.PostURL)
+	}
 
 	return nil
 }
 
+// filterByStatus applies --status-map, the status and type filters and then --only-errors to the fetched tasks
+func filterByStatus(response evidence.TransitionCheckResponse, config *AppConfig) evidence.TransitionCheckResponse {
+	// Renamed first, so the filters and reports see the normalized statuses
+	response, renamed := evidence.MapStatuses(response, config.StatusMap)
+	if renamed > 0 {
+		fmt.Printf("Renamed the status of %d tasks with --status-map\n", renamed)
+	}
+
+	response, dropped := evidence.IncludeStatuses(response, config.IncludeStatuses)
+	if dropped > 0 {
+		fmt.Printf("Excluded %d tasks not in status %s\n", dropped, strings.Join(config.IncludeStatuses, ", "))
+	}
+
+	response, excluded := evidence.ExcludeStatuses(response, config.ExcludeStatuses)
+	if excluded > 0 {
+		fmt.Printf("Excluded %d tasks with status %s\n", excluded, strings.Join(config.ExcludeStatuses, ", "))
+	}
+
+	response, dropped = evidence.IncludeTypes(response, config.IncludeTypes)
+	if dropped > 0 {
+		fmt.Printf("Excluded %d tasks not of type %s\n", dropped, strings.Join(config.IncludeTypes, ", "))
+	}
+
+	response, excluded = evidence.ExcludeTypes(response, config.ExcludeTypes)
+	if excluded > 0 {
+		fmt.Printf("Excluded %d tasks of type %s\n", excluded, strings.Join(config.ExcludeTypes, ", "))
+	}
+
+	if config.OnlyErrors {
+		var fetched int
+		response, fetched = evidence.OnlyErrors(response)
+		if len(response.Tasks) == 0 {
+			fmt.Println("No tasks failed to fetch, writing an empty task list (--only-errors)")
+		} else {
+			fmt.Printf("Kept the %d tasks that failed to fetch, dropped %d fetched tasks (--only-errors)\n", len(response.Tasks), fetched)
+		}
+	}
+
+	response, pruned := evidence.TransitionsSince(response, config.TransitionsSince)
+	if pruned > 0 {
+		fmt.Printf("Dropped %d transitions before %s\n", pruned, config.TransitionsSince.Format(transitionsSinceLayout))
+	}
+	return response
+}
+
+// marshalResults encodes the evidence JSON with the --fields selection, pretty-printed unless
+// --compact is set
+func marshalResults(response evidence.TransitionCheckResponse, config *AppConfig) ([]byte, error) {
+	var value interface{} = response
+	if len(config.Fields) > 0 {
+		selected, err := evidence.SelectFields(response, config.Fields)
+		if err != nil {
+			return nil, err
+		}
+		value = selected
+	}
+
+	if config.Compact {
+		return json.Marshal(value)
+	}
+	return json.MarshalIndent(value, "", "  ")
+}
+
+// jsonToYAML converts the JSON results to YAML, so the YAML output has the same keys (the JSON
+// tags, including --fields selection and omitted empty fields) in the same order
+func jsonToYAML(jsonBytes []byte) ([]byte, error) {
+	var node yaml.Node
+	if err := yaml.Unmarshal(jsonBytes, &node); err != nil {
+		return nil, err
+	}
+	blockStyle(&node)
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// blockStyle clears the flow and quoting styles a node parsed from JSON carries, so it is written
+// as block YAML. Strings that would read back as another type (e.g. "true") are still quoted.
+func blockStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		blockStyle(child)
+	}
+}
+
+// writeChecksum writes the content hash of the JSON output to OUTPUT.sha256 (--checksum). The hash
+// covers the canonical JSON, so it doesn't depend on --compact or on how the file is re-indented.
+func writeChecksum(outputFile string, jsonBytes []byte) error {
+	hash, err := evidence.ContentHash(jsonBytes)
+	if err != nil {
+		return fmt.Errorf("error hashing JSON output: %v", err)
+	}
+	checksumFile := outputFile + ".sha256"
+	if err := writeToFile(checksumFile, []byte(hash+"\n")); err != nil {
+		return fmt.Errorf("error writing checksum file: %v", err)
+	}
+	fmt.Printf("Content hash saved to: %s\n", checksumFile)
+	return nil
+}
+
+// outputFileWithExtension derives a report file name from the JSON output file,
+// e.g. evidence.json becomes evidence.md
+func outputFileWithExtension(outputFile, extension string) string {
+	return strings.TrimSuffix(outputFile, filepath.Ext(outputFile)) + extension
+}
+
+// newResponseMeta describes the evidence file being written; source is nil when it wasn't
+// produced from a git checkout
+func newResponseMeta(source *evidence.SourceInfo) *evidence.ResponseMeta {
+	return &evidence.ResponseMeta{
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		ToolVersion:   version,
+		SchemaVersion: evidence.SchemaVersion,
+		Source:        source,
+	}
+}
+
+// loadExistingResults loads the output file that --append merges into.
+// It returns nil when the file doesn't exist yet, and an error when it can't be parsed so a
+// corrupt file is never silently overwritten.
+func loadExistingResults(outputFile string) (*evidence.TransitionCheckResponse, error) {
+	if _, err := os.Stat(outputFile); errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+
+	existing, err := evidence.LoadResponseFile(outputFile)
+	if err != nil {
+		return nil, fmt.Errorf("cannot append to %s, fix or remove it first: %w", outputFile, err)
+	}
+	return &existing, nil
+}
+
 // determineExecutionMode determines which mode to run based on flags and arguments
 func determineExecutionMode(flags *FlagConfig, args []string, config *AppConfig) error {
 	// Handle markdown generation mode
 	if flags.GenerateMarkdown {
-		return runMarkdownMode(flags)
+		return runMarkdownMode(config)
+	}
+
+	// Handle evidence file merge mode
+	if flags.Merge {
+		return runMergeMode(args, config)
 	}
 
 	// Handle legacy extract-from-git mode
@@ -228,36 +812,92 @@ func determineExecutionMode(flags *FlagConfig, args []string, config *AppConfig)
 		return runLegacyExtractFromGit(args)
 	}
 
+	// Handle the project status listing helper
+	if config.ListStatuses != "" {
+		return runListStatusesMode(config)
+	}
+
+	// Handle JQL mode, where JIRA itself supplies the ticket set
+	if config.JQL != "" {
+		return runJQLMode(config)
+	}
+
+	// Handle scan-file mode, where a text file such as a PR description supplies the ticket set
+	if config.ScanFile != "" {
+		return runScanFileMode(config)
+	}
+
+	// An explicit --from/--to range, --base-branch or --commits-file replaces the positional commit argument
+	usingRefRange := config.FromRef != "" || config.BaseBranch != "" || config.CommitsFile != ""
+
+	// --default-head analyzes the latest commit when neither a commit nor JIRA IDs are given
+	defaultHead := len(args) == 0 && !usingRefRange && config.DefaultHead
+
 	// Check if we have required arguments
-	if len(args) == 0 {
+	if len(args) == 0 && !usingRefRange && !defaultHead {
 		return fmt.Errorf("missing required arguments")
 	}
 
 	// Check if this is direct JIRA ID processing mode
-	if !flags.ExtractOnly && len(args) > 0 {
+	if !flags.ExtractOnly && !usingRefRange && len(args) > 0 {
 		// Check if all arguments match JIRA ID pattern
-		regex, err := regexp.Compile(config.JIRAIDRegex)
+		regex, err := evidence.CompileJiraIDRegex(config.JIRAIDRegex, config.ProjectRegexes)
+		if err == nil && config.IgnoreCase {
+			regex, err = regexp.Compile("(?i)" + regex.String())
+		}
 		if err == nil && allArgsMatchPattern(args, regex) {
 			// All arguments are JIRA IDs - process them directly
-			config.JIRAIDs = args
+			jiraIDs := args
+			if config.IgnoreCase {
+				jiraIDs = make([]string, len(args))
+				for i, arg := range args {
+					jiraIDs[i] = strings.ToUpper(arg)
+				}
+			}
+
+			// A repeated ID would be fetched and written once per repeat
+			var duplicates []string
+			config.JIRAIDs, duplicates = dedupeJiraIDs(jiraIDs)
+			if config.WarnDuplicates && len(duplicates) > 0 {
+				evidence.PrintWarning("Duplicate JIRA IDs given, fetching each once: %s", strings.Join(duplicates, ", "))
+			}
+			if config.IfNewer {
+				evidence.PrintWarning("--if-newer only applies to commit-based runs, fetching the given JIRA IDs")
+			}
 			return processDirectJiraIDs(config)
 		}
 	}
 
 	// Otherwise, we're in git-based mode
-	config.StartCommit = args[0]
+	if !usingRefRange && !defaultHead {
+		config.StartCommit = args[0]
+	}
 
 	// Check if we're in a git repository
-	git := NewGitService()
+	git := evidence.NewGitService().WithStats(config.Stats).WithVerbose(config.Verbose)
 	if err := git.CheckRepository(); err != nil {
 		return err
 	}
 
+	if defaultHead {
+		head, err := git.GetHeadCommit()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("No commit given, using HEAD (%s)\n", head)
+		config.StartCommit = head
+	}
+
 	// Run the appropriate mode
+	run := func() error { return runFullMode(config) }
 	if config.ExtractOnly {
-		return runExtractOnlyMode(config)
+		run = func() error { return runExtractOnlyMode(config) }
+	}
+
+	if config.Watch {
+		return runWatchMode(git, config, run)
 	}
-	return runFullMode(config)
+	return run()
 }
 
 // allArgsMatchPattern checks if all arguments match the given regex pattern
@@ -271,22 +911,53 @@ func allArgsMatchPattern(args []string, regex *regexp.Regexp) bool {
 }
 
 // runMarkdownMode runs the markdown generation mode
-func runMarkdownMode(flags *FlagConfig) error {
-	// Determine input and output files
-	inputFile := getOrDefault(flags.OutputFile, os.Getenv("OUTPUT_FILE"), DefaultOutputFile)
-	outputFile := getOrDefault(flags.MarkdownOutput, "transformed_jira_data.md")
+func runMarkdownMode(config *AppConfig) error {
+	// The input is the configured output file, so -o, OUTPUT_FILE and output_file all apply
+	inputFile := config.OutputFile
+	outputFile := getOrDefault(config.MarkdownOutput, "transformed_jira_data.md")
 
 	fmt.Println("=== Markdown Generation Mode ===")
 	fmt.Printf("Input JSON file: %s\n", inputFile)
-	fmt.Printf("Output Markdown file: %s\n", outputFile)
+
+	// Generate markdown from JSON, as one report or one file per ticket
+	var err error
+	if config.SplitOutput != "" {
+		fmt.Printf("Output Markdown directory: %s\n", config.SplitOutput)
+		fmt.Println("")
+		err = evidence.GenerateSplitMarkdownFromJSON(inputFile, config.SplitOutput, newMarkdownOptions(config))
+	} else {
+		fmt.Printf("Output Markdown file: %s\n", outputFile)
+		fmt.Println("")
+		err = evidence.GenerateMarkdownFromJSON(inputFile, outputFile, newMarkdownOptions(config))
+	}
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("")
+	evidence.PrintSuccess("=== Markdown generation completed successfully ===")
+	return nil
+}
+
+// runMergeMode merges multiple evidence JSON files into the configured output file
+func runMergeMode(args []string, config *AppConfig) error {
+	if len(args) < 2 {
+		return fmt.Errorf("merge mode requires at least two input files")
+	}
+
+	strategy := getOrDefault(config.MergeStrategy, MergeKeepLast)
+
+	fmt.Println("=== Evidence Merge Mode ===")
+	fmt.Printf("Input files: %s\n", strings.Join(args, ", "))
+	fmt.Printf("Output file: %s\n", config.OutputFile)
+	fmt.Printf("Duplicate strategy: keep %s\n", strategy)
 	fmt.Println("")
 
-	// Generate markdown from JSON
-	if err := GenerateMarkdownFromJSON(inputFile, outputFile); err != nil {
+	if err := MergeJSONFiles(args, config.OutputFile, strategy); err != nil {
 		return err
 	}
 
 	fmt.Println("")
-	fmt.Println("=== Markdown generation completed successfully ===")
+	evidence.PrintSuccess("=== Merge completed successfully ===")
 	return nil
 }
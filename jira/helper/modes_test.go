@@ -1,16 +1,28 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"jira-helper/evidence"
 )
 
 // Mock GitService for testing
@@ -59,14 +71,14 @@ func (m *MockGitService) CheckRepository() error {
 
 // MockJiraClientForModes for testing modes
 type MockJiraClientForModes struct {
-	FetchDetailsFunc func(jiraIDs []string) TransitionCheckResponse
+	FetchDetailsFunc func(jiraIDs []string) evidence.TransitionCheckResponse
 }
 
-func (m *MockJiraClientForModes) FetchJiraDetails(jiraIDs []string) TransitionCheckResponse {
+func (m *MockJiraClientForModes) FetchJiraDetails(jiraIDs []string) evidence.TransitionCheckResponse {
 	if m.FetchDetailsFunc != nil {
 		return m.FetchDetailsFunc(jiraIDs)
 	}
-	return TransitionCheckResponse{}
+	return evidence.TransitionCheckResponse{}
 }
 
 func TestRunExtractOnlyMode(t *testing.T) {
@@ -80,6 +92,7 @@ func TestRunExtractOnlyMode(t *testing.T) {
 		config         *AppConfig
 		mockGit        *MockGitService
 		expectError    bool
+		expectedErr    error
 		expectedOutput string
 	}{
 		{
@@ -123,6 +136,28 @@ func TestRunExtractOnlyMode(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "No JIRA IDs with fail-on-empty",
+			config: &AppConfig{
+				StartCommit:  "abc123",
+				JIRAIDRegex:  "[A-Z]+-[0-9]+",
+				SingleCommit: true,
+				FailOnEmpty:  true,
+			},
+			mockGit: &MockGitService{
+				GetBranchInfoFunc: func() (string, string, string, error) {
+					return "main", "def456", "", nil
+				},
+				ValidateHEADFunc: func() error {
+					return nil
+				},
+				ExtractJiraIDsFunc: func(startCommit, jiraIDRegex, currentJiraID string, singleCommit bool) ([]string, error) {
+					return []string{}, nil
+				},
+			},
+			expectError: true,
+			expectedErr: ErrNoJiraIDs,
+		},
 		{
 			name: "Error getting branch info",
 			config: &AppConfig{
@@ -147,7 +182,7 @@ func TestRunExtractOnlyMode(t *testing.T) {
 					return "main", "abc123", "", nil
 				},
 				ValidateHEADFunc: func() error {
-					return &GitError{Operation: "rev-parse", Err: errors.New("bad HEAD")}
+					return &evidence.GitError{Operation: "rev-parse", Err: errors.New("bad HEAD")}
 				},
 			},
 			expectError: false, // Should exit gracefully
@@ -197,6 +232,9 @@ func TestRunExtractOnlyMode(t *testing.T) {
 
 			if tt.expectError {
 				assert.Error(t, err)
+				if tt.expectedErr != nil {
+					assert.ErrorIs(t, err, tt.expectedErr)
+				}
 			} else {
 				assert.NoError(t, err)
 				if tt.expectedOutput != "" {
@@ -241,6 +279,9 @@ func runExtractOnlyModeWithGit(config *AppConfig, git *MockGitService) error {
 
 	if len(jiraIDs) == 0 {
 		fmt.Println("No JIRA IDs found")
+		if config.FailOnEmpty {
+			return ErrNoJiraIDs
+		}
 		return nil
 	}
 
@@ -442,14 +483,14 @@ func TestSaveJiraResults(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		response    TransitionCheckResponse
+		response    evidence.TransitionCheckResponse
 		config      *AppConfig
 		expectError bool
 	}{
 		{
 			name: "Save valid response",
-			response: TransitionCheckResponse{
-				Tasks: []JiraTransitionResult{
+			response: evidence.TransitionCheckResponse{
+				Tasks: []evidence.JiraTransitionResult{
 					{
 						Key:     "EV-123",
 						Link:    "https://example.atlassian.net/browse/EV-123",
@@ -466,8 +507,8 @@ func TestSaveJiraResults(t *testing.T) {
 		},
 		{
 			name: "Save to invalid path",
-			response: TransitionCheckResponse{
-				Tasks: []JiraTransitionResult{},
+			response: evidence.TransitionCheckResponse{
+				Tasks: []evidence.JiraTransitionResult{},
 			},
 			config: &AppConfig{
 				OutputFile: "/root/invalid/path/output.json",
@@ -483,7 +524,7 @@ func TestSaveJiraResults(t *testing.T) {
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
-			err := saveJiraResults(tt.response, tt.config)
+			err := saveJiraResults(tt.response, tt.config, false)
 
 			w.Close()
 			os.Stdout = oldStdout
@@ -504,15 +545,907 @@ func TestSaveJiraResults(t *testing.T) {
 				assert.NoError(t, err)
 
 				// Verify JSON is valid
-				var result TransitionCheckResponse
+				var result evidence.TransitionCheckResponse
 				err = json.Unmarshal(data, &result)
 				assert.NoError(t, err)
 				assert.Equal(t, tt.response.Tasks, result.Tasks)
+
+				// Verify the generation metadata
+				require.NotNil(t, result.Meta)
+				assert.Equal(t, version, result.Meta.ToolVersion)
+				assert.Equal(t, evidence.SchemaVersion, result.Meta.SchemaVersion)
+				_, err = time.Parse(time.RFC3339, result.Meta.GeneratedAt)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.config.Source, result.Meta.Source)
 			}
 		})
 	}
 }
 
+func TestSaveJiraResultsCompact(t *testing.T) {
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	response := evidence.TransitionCheckResponse{Tasks: []evidence.JiraTransitionResult{{Key: "EV-1", Status: "Done"}}}
+
+	tests := []struct {
+		name    string
+		compact bool
+	}{
+		{"Pretty-printed by default", false},
+		{"Compact", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFile := filepath.Join(t.TempDir(), "evidence.json")
+			require.NoError(t, saveJiraResults(response, &AppConfig{OutputFile: outputFile, Compact: tt.compact}, false))
+
+			data, err := os.ReadFile(outputFile)
+			require.NoError(t, err)
+			if tt.compact {
+				assert.NotContains(t, string(data), "\n")
+				assert.Contains(t, string(data), `"tasks":[{"key":"EV-1"`)
+			} else {
+				assert.Contains(t, string(data), "\n  \"tasks\": [\n")
+			}
+
+			// Either form reads back the same
+			result, err := evidence.LoadResponseFile(outputFile)
+			require.NoError(t, err)
+			assert.Equal(t, response.Tasks, result.Tasks)
+		})
+	}
+}
+
+func TestJSONToYAML(t *testing.T) {
+	response := evidence.TransitionCheckResponse{
+		Meta: &evidence.ResponseMeta{GeneratedAt: "2024-05-01T09:30:00Z", ToolVersion: "v1.4.0", SchemaVersion: 1},
+		Tasks: []evidence.JiraTransitionResult{{
+			Key:         "EV-1",
+			Status:      "Done",
+			Description: "Line one\n\nLine two: with a colon",
+			Resolution:  "true",
+			Priority:    "123",
+			Transitions: []evidence.Transition{{FromStatus: "To Do", ToStatus: "Done"}},
+		}},
+	}
+	jsonBytes, err := json.Marshal(response)
+	require.NoError(t, err)
+
+	yamlBytes, err := jsonToYAML(jsonBytes)
+	require.NoError(t, err)
+	output := string(yamlBytes)
+
+	// Keys are the JSON tags, in the same order, in block style
+	assert.True(t, strings.HasPrefix(output, "meta:\n  generated_at: \"2024-05-01T09:30:00Z\"\n  tool_version: v1.4.0\n  schema_version: 1\ntasks:\n  - key: EV-1\n"), output)
+	assert.Less(t, strings.Index(output, "transitions:"), strings.Index(output, "from_status: To Do"))
+	assert.NotContains(t, output, "{")
+
+	// Strings that look like other types stay strings
+	var decoded struct {
+		Tasks []map[string]interface{} `yaml:"tasks"`
+	}
+	require.NoError(t, yaml.Unmarshal(yamlBytes, &decoded))
+	require.Len(t, decoded.Tasks, 1)
+	assert.Equal(t, "true", decoded.Tasks[0]["resolution"])
+	assert.Equal(t, "123", decoded.Tasks[0]["priority"])
+	assert.Equal(t, "Line one\n\nLine two: with a colon", decoded.Tasks[0]["description"])
+}
+
+func TestSaveJiraResultsYAML(t *testing.T) {
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	response := evidence.TransitionCheckResponse{Tasks: []evidence.JiraTransitionResult{{Key: "EV-1", Status: "Done"}}}
+	outputFile := filepath.Join(t.TempDir(), "evidence.json")
+	require.NoError(t, saveJiraResults(response, &AppConfig{OutputFile: outputFile, Formats: []string{FormatYAML}, Fields: []string{"key", "status"}}, false))
+
+	assert.NoFileExists(t, outputFile)
+	data, err := os.ReadFile(filepath.Join(filepath.Dir(outputFile), "evidence.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "tasks:\n  - key: EV-1\n    status: Done\n")
+}
+
+func TestSaveJiraResultsChecksum(t *testing.T) {
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	response := evidence.TransitionCheckResponse{Tasks: []evidence.JiraTransitionResult{{Key: "EV-1", Status: "Done"}}}
+
+	for _, compact := range []bool{false, true} {
+		outputFile := filepath.Join(t.TempDir(), "evidence.json")
+		require.NoError(t, saveJiraResults(response, &AppConfig{OutputFile: outputFile, Compact: compact, Checksum: true}, false))
+
+		data, err := os.ReadFile(outputFile)
+		require.NoError(t, err)
+		expected, err := evidence.ContentHash(data)
+		require.NoError(t, err)
+
+		checksum, err := os.ReadFile(outputFile + ".sha256")
+		require.NoError(t, err)
+		assert.Equal(t, expected+"\n", string(checksum))
+	}
+
+	t.Run("not written by default", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "evidence.json")
+		require.NoError(t, saveJiraResults(response, &AppConfig{OutputFile: outputFile}, false))
+
+		assert.NoFileExists(t, outputFile+".sha256")
+	})
+}
+
+func TestSaveJiraResultsFields(t *testing.T) {
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	response := evidence.TransitionCheckResponse{Tasks: []evidence.JiraTransitionResult{{
+		Key:         "EV-1",
+		Status:      "Done",
+		Description: "Fix the login page",
+		Transitions: []evidence.Transition{{FromStatus: "To Do", ToStatus: "Done"}},
+	}}}
+
+	outputFile := filepath.Join(t.TempDir(), "evidence.json")
+	config := &AppConfig{OutputFile: outputFile, Compact: true, Fields: []string{"transitions", "key", "status"}}
+	require.NoError(t, saveJiraResults(response, config, false))
+
+	data, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"tasks":[{"key":"EV-1","status":"Done","transitions":[{"from_status":"To Do"`)
+	assert.NotContains(t, string(data), "description")
+	assert.Contains(t, string(data), `"meta":{`)
+}
+
+func TestSaveJiraResultsSource(t *testing.T) {
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	outputFile := filepath.Join(t.TempDir(), "evidence.json")
+	source := &evidence.SourceInfo{Repository: "https://github.com/example/app.git", Branch: "main", Commit: "abc123"}
+	response := evidence.TransitionCheckResponse{Tasks: []evidence.JiraTransitionResult{{Key: "EV-1", Status: "Done"}}}
+
+	require.NoError(t, saveJiraResults(response, &AppConfig{OutputFile: outputFile, Source: source}, false))
+
+	data, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"repository": "https://github.com/example/app.git"`)
+
+	result, err := evidence.LoadResponseFile(outputFile)
+	require.NoError(t, err)
+	require.NotNil(t, result.Meta)
+	assert.Equal(t, source, result.Meta.Source)
+}
+
+func TestSaveJiraResultsAppend(t *testing.T) {
+	tempDir := t.TempDir()
+	fresh := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-2", Status: "Done"},
+			{Key: "EV-3", Status: "To Do"},
+		},
+	}
+
+	// Silence progress output and the changed-status warning
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() {
+		os.Stdout, os.Stderr = oldStdout, oldStderr
+		devNull.Close()
+	}()
+
+	t.Run("Missing output file is created", func(t *testing.T) {
+		outputFile := filepath.Join(tempDir, "new.json")
+
+		require.NoError(t, saveJiraResults(fresh, &AppConfig{OutputFile: outputFile, Append: true}, false))
+
+		result, err := evidence.LoadResponseFile(outputFile)
+		require.NoError(t, err)
+		assert.Equal(t, fresh.Tasks, result.Tasks)
+	})
+
+	t.Run("New tasks are merged into the existing file", func(t *testing.T) {
+		outputFile := filepath.Join(tempDir, "existing.json")
+		require.NoError(t, os.WriteFile(outputFile, []byte(`{"tasks":[{"key":"EV-1","status":"Done"},{"key":"EV-2","status":"In Progress"}]}`), 0644))
+
+		require.NoError(t, saveJiraResults(fresh, &AppConfig{OutputFile: outputFile, Append: true}, false))
+
+		result, err := evidence.LoadResponseFile(outputFile)
+		require.NoError(t, err)
+		require.Len(t, result.Tasks, 3)
+		assert.Equal(t, "EV-1", result.Tasks[0].Key)
+		assert.Equal(t, "EV-2", result.Tasks[1].Key)
+		assert.Equal(t, "Done", result.Tasks[1].Status, "the newly fetched copy wins")
+		assert.Equal(t, "EV-3", result.Tasks[2].Key)
+	})
+
+	t.Run("Corrupt existing file is not overwritten", func(t *testing.T) {
+		outputFile := filepath.Join(tempDir, "corrupt.json")
+		require.NoError(t, os.WriteFile(outputFile, []byte(`{"tasks": [`), 0644))
+
+		err := saveJiraResults(fresh, &AppConfig{OutputFile: outputFile, Append: true}, false)
+
+		assert.ErrorContains(t, err, "cannot append to")
+		data, _ := os.ReadFile(outputFile)
+		assert.Equal(t, `{"tasks": [`, string(data))
+	})
+
+	t.Run("Without append the file is overwritten", func(t *testing.T) {
+		outputFile := filepath.Join(tempDir, "overwrite.json")
+		require.NoError(t, os.WriteFile(outputFile, []byte(`{"tasks":[{"key":"EV-1","status":"Done"}]}`), 0644))
+
+		require.NoError(t, saveJiraResults(fresh, &AppConfig{OutputFile: outputFile}, false))
+
+		result, err := evidence.LoadResponseFile(outputFile)
+		require.NoError(t, err)
+		assert.Equal(t, fresh.Tasks, result.Tasks)
+	})
+}
+
+func TestSaveJiraResultsFormats(t *testing.T) {
+	response := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Type: "Task"},
+		},
+	}
+
+	// Silence progress output
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	t.Run("All formats from one response", func(t *testing.T) {
+		outputFile := filepath.Join(t.TempDir(), "evidence.json")
+
+		require.NoError(t, saveJiraResults(response, &AppConfig{OutputFile: outputFile, Formats: []string{FormatJSON, FormatMarkdown, FormatHTML, FormatText}}, false))
+
+		result, err := evidence.LoadResponseFile(outputFile)
+		require.NoError(t, err)
+		assert.Equal(t, response.Tasks, result.Tasks)
+
+		markdown, err := os.ReadFile(strings.TrimSuffix(outputFile, ".json") + ".md")
+		require.NoError(t, err)
+		assert.Contains(t, string(markdown), "# JIRA Tasks Report")
+		assert.Contains(t, string(markdown), "| EV-1 | Done | Task |")
+
+		html, err := os.ReadFile(strings.TrimSuffix(outputFile, ".json") + ".html")
+		require.NoError(t, err)
+		assert.Contains(t, string(html), "<h3>1. EV-1</h3>")
+
+		text, err := os.ReadFile(strings.TrimSuffix(outputFile, ".json") + ".txt")
+		require.NoError(t, err)
+		assert.Contains(t, string(text), "1. EV-1\n")
+	})
+
+	t.Run("Only the requested formats are written", func(t *testing.T) {
+		outputDir := t.TempDir()
+		outputFile := filepath.Join(outputDir, "evidence.json")
+
+		require.NoError(t, saveJiraResults(response, &AppConfig{OutputFile: outputFile, Formats: []string{FormatMarkdown}}, false))
+
+		entries, err := os.ReadDir(outputDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "evidence.md", entries[0].Name())
+	})
+}
+
+func TestSaveJiraResultsExcludeStatus(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "evidence.json")
+	response := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1", Status: "Done"},
+			{Key: "EV-2", Status: "In Progress"},
+			{Key: "EV-3", Status: "CLOSED"},
+		},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	err := saveJiraResults(response, &AppConfig{OutputFile: outputFile, ExcludeStatuses: []string{"done", "Closed"}}, false)
+	w.Close()
+	os.Stdout = oldStdout
+	output, _ := io.ReadAll(r)
+	require.NoError(t, err)
+
+	assert.Contains(t, string(output), "Excluded 2 tasks with status done, Closed")
+
+	result, err := evidence.LoadResponseFile(outputFile)
+	require.NoError(t, err)
+	require.Len(t, result.Tasks, 1)
+	assert.Equal(t, "EV-2", result.Tasks[0].Key)
+}
+
+func TestSaveJiraResultsOnlyErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		tasks          []evidence.JiraTransitionResult
+		expectedKeys   []string
+		expectedOutput string
+	}{
+		{
+			name: "Keeps the failures",
+			tasks: []evidence.JiraTransitionResult{
+				{Key: "EV-1", Status: "Done"},
+				{Key: "EV-2", Status: evidence.ErrorStatus, ErrorCode: 404, Description: "Error 404: Could not retrieve issue"},
+			},
+			expectedKeys:   []string{"EV-2"},
+			expectedOutput: "Kept the 1 tasks that failed to fetch, dropped 1 fetched tasks",
+		},
+		{
+			name:           "Writes an empty list without failures",
+			tasks:          []evidence.JiraTransitionResult{{Key: "EV-1", Status: "Done"}},
+			expectedOutput: "No tasks failed to fetch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputFile := filepath.Join(t.TempDir(), "evidence.json")
+
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+			err := saveJiraResults(evidence.TransitionCheckResponse{Tasks: tt.tasks}, &AppConfig{OutputFile: outputFile, OnlyErrors: true}, false)
+			w.Close()
+			os.Stdout = oldStdout
+			output, _ := io.ReadAll(r)
+			require.NoError(t, err)
+			assert.Contains(t, string(output), tt.expectedOutput)
+
+			data, err := os.ReadFile(outputFile)
+			require.NoError(t, err)
+			if tt.expectedKeys == nil {
+				assert.Contains(t, string(data), `"tasks": []`)
+			}
+			result, err := evidence.LoadResponseFile(outputFile)
+			require.NoError(t, err)
+			var keys []string
+			for _, task := range result.Tasks {
+				keys = append(keys, task.Key)
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+func TestFilterByStatus(t *testing.T) {
+	response := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1", Status: "Done"},
+			{Key: "EV-2", Status: "In Review"},
+			{Key: "EV-3", Status: "QA"},
+			{Key: "EV-4", Status: evidence.ErrorStatus},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		statusMap    map[string]string
+		include      []string
+		exclude      []string
+		expectedKeys []string
+	}{
+		{"No filters", nil, nil, nil, []string{"EV-1", "EV-2", "EV-3", "EV-4"}},
+		{"Filters match mapped statuses", map[string]string{"in review": "QA"}, []string{"QA"}, nil, []string{"EV-2", "EV-3", "EV-4"}},
+		{"Mapped statuses no longer match their old name", map[string]string{"in review": "QA"}, nil, []string{"In Review"}, []string{"EV-1", "EV-2", "EV-3", "EV-4"}},
+		{"Include only keeps failures", nil, []string{"In Review", "QA"}, nil, []string{"EV-2", "EV-3", "EV-4"}},
+		{"Exclude only", nil, nil, []string{"done"}, []string{"EV-2", "EV-3", "EV-4"}},
+		{"Include runs before exclude", nil, []string{"In Review", "QA"}, []string{"qa"}, []string{"EV-2", "EV-4"}},
+		{"Failures are dropped when explicitly excluded", nil, []string{"In Review"}, []string{"Error"}, []string{"EV-2"}},
+		{"Excluding an included status leaves only failures", nil, []string{"Done"}, []string{"Done"}, []string{"EV-4"}},
+	}
+
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filtered := filterByStatus(response, &AppConfig{StatusMap: tt.statusMap, IncludeStatuses: tt.include, ExcludeStatuses: tt.exclude})
+
+			var keys []string
+			for _, task := range filtered.Tasks {
+				keys = append(keys, task.Key)
+			}
+			assert.Equal(t, tt.expectedKeys, keys)
+		})
+	}
+}
+
+func TestFilterByStatusTypes(t *testing.T) {
+	response := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1", Type: "Bug", Status: "Done"},
+			{Key: "EV-2", Type: "Epic", Status: "In Review"},
+			{Key: "EV-3", Type: "Incident", Status: "Done"},
+			{Key: "EV-4", Status: evidence.ErrorStatus},
+		},
+	}
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+	filtered := filterByStatus(response, &AppConfig{
+		IncludeStatuses: []string{"Done"},
+		IncludeTypes:    []string{"bug", "epic"},
+		ExcludeTypes:    []string{"EPIC"},
+	})
+	w.Close()
+	os.Stdout = oldStdout
+	output, _ := io.ReadAll(r)
+
+	var keys []string
+	for _, task := range filtered.Tasks {
+		keys = append(keys, task.Key)
+	}
+	assert.Equal(t, []string{"EV-1", "EV-4"}, keys)
+	assert.Contains(t, string(output), "Excluded 1 tasks not of type bug, epic")
+}
+
+func TestOutputFileWithExtension(t *testing.T) {
+	assert.Equal(t, "evidence.md", outputFileWithExtension("evidence.json", ".md"))
+	assert.Equal(t, "out/data.html", outputFileWithExtension("out/data.json", ".html"))
+	assert.Equal(t, "evidence.md", outputFileWithExtension("evidence", ".md"))
+}
+
+func TestSaveJiraResultsAnonymize(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "output.json")
+	assignee := "Jane Doe"
+	response := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Assignee: &assignee, Reporter: "Jane Doe"},
+		},
+	}
+
+	// Silence progress output
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	err := saveJiraResults(response, &AppConfig{OutputFile: outputFile, Anonymize: true}, false)
+	os.Stdout = oldStdout
+	devNull.Close()
+
+	require.NoError(t, err)
+	data, err := os.ReadFile(outputFile)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "Jane Doe")
+
+	result, err := evidence.LoadResponseFile(outputFile)
+	require.NoError(t, err)
+	assert.Equal(t, "User A", *result.Tasks[0].Assignee)
+	assert.Equal(t, "User A", result.Tasks[0].Reporter)
+}
+
+func TestSaveJiraResultsTransitionsCSV(t *testing.T) {
+	dir := t.TempDir()
+	csvFile := filepath.Join(dir, "reports", "transitions.csv")
+	response := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Transitions: []evidence.Transition{
+				{FromStatus: "To Do", ToStatus: "Done", Author: "Jane Doe", AuthorEmail: "jane@example.com", TransitionTime: "2024-03-05T05:06:07.000+0000"},
+			}},
+			{Key: "EV-2", Status: "In Review", Transitions: []evidence.Transition{
+				{FromStatus: "To Do", ToStatus: "In Review", Author: "John Smith", TransitionTime: "2024-03-06T05:06:07.000+0000"},
+			}},
+		},
+	}
+
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	err := saveJiraResults(response, &AppConfig{
+		OutputFile:      filepath.Join(dir, "output.json"),
+		TransitionsCSV:  csvFile,
+		ExcludeStatuses: []string{"In Review"},
+		Anonymize:       true,
+	}, false)
+	os.Stdout = oldStdout
+	devNull.Close()
+
+	require.NoError(t, err)
+	data, err := os.ReadFile(csvFile)
+	require.NoError(t, err)
+	assert.Equal(t, "key,from,to,author,author_email,time\nEV-1,To Do,Done,User A,User A,2024-03-05T05:06:07.000+0000\n", string(data),
+		"the CSV is written from the filtered, anonymized response")
+}
+
+func TestFetchJiraDetailsUntilInterrupted(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		if key == "EV-2" {
+			cancel()
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprintf(w, `{"key":%q,"fields":{"status":{"name":"Done"}}}`, key)
+	}))
+	defer server.Close()
+
+	t.Setenv("JIRA_API_TOKEN", "token")
+	t.Setenv("JIRA_URL", server.URL)
+	t.Setenv("JIRA_USERNAME", "user@example.com")
+	jiraClient, err := evidence.NewJiraClient(evidence.JiraClientOptions{})
+	require.NoError(t, err)
+
+	posts := 0
+	intake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posts++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer intake.Close()
+
+	// Silence progress output and warnings
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() {
+		os.Stdout, os.Stderr = oldStdout, oldStderr
+		devNull.Close()
+	}()
+
+	config := &AppConfig{OutputFile: filepath.Join(t.TempDir(), "partial.json"), PostURL: intake.URL, Checksum: true}
+	_, err = fetchJiraDetailsUntil(ctx, jiraClient, []string{"EV-1", "EV-2", "EV-3"}, config)
+
+	assert.ErrorIs(t, err, ErrInterrupted)
+	saved, loadErr := evidence.LoadResponseFile(config.OutputFile)
+	require.NoError(t, loadErr)
+	require.Len(t, saved.Tasks, 1)
+	assert.Equal(t, "EV-1", saved.Tasks[0].Key)
+	require.NotNil(t, saved.Meta)
+	assert.True(t, saved.Meta.Partial)
+	assert.Zero(t, posts, "partial output is never posted")
+	assert.NoFileExists(t, config.OutputFile+".sha256", "partial output is never checksummed")
+}
+
+func TestDetermineExecutionModeDuplicateIDs(t *testing.T) {
+	tests := []struct {
+		name           string
+		args           []string
+		ignoreCase     bool
+		warnDuplicates bool
+		expectedKeys   []string
+		expectWarning  string
+	}{
+		{
+			name:         "Repeated IDs are fetched once",
+			args:         []string{"EV-1", "EV-2", "EV-1", "EV-3", "EV-2"},
+			expectedKeys: []string{"EV-1", "EV-2", "EV-3"},
+		},
+		{
+			name:           "Repeats are reported with warn-duplicates",
+			args:           []string{"EV-1", "EV-2", "EV-1", "EV-2", "EV-1"},
+			warnDuplicates: true,
+			expectedKeys:   []string{"EV-1", "EV-2"},
+			expectWarning:  "Duplicate JIRA IDs given, fetching each once: EV-1, EV-2",
+		},
+		{
+			name:           "IDs differing in case repeat with ignore-case",
+			args:           []string{"ev-1", "EV-1"},
+			ignoreCase:     true,
+			warnDuplicates: true,
+			expectedKeys:   []string{"EV-1"},
+			expectWarning:  "Duplicate JIRA IDs given, fetching each once: EV-1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			requests := map[string]int{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+				mu.Lock()
+				requests[key]++
+				mu.Unlock()
+				fmt.Fprintf(w, `{"key":%q,"fields":{"status":{"name":"Done"}}}`, key)
+			}))
+			defer server.Close()
+
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+			r, w, _ := os.Pipe()
+			os.Stdout, os.Stderr = devNull, w
+
+			config := &AppConfig{
+				JIRAURL:        server.URL,
+				JIRAToken:      "token",
+				JIRAUsername:   "user@example.com",
+				JIRAIDRegex:    DefaultJIRAIDRegex,
+				OutputFile:     filepath.Join(t.TempDir(), "evidence.json"),
+				IgnoreCase:     tt.ignoreCase,
+				WarnDuplicates: tt.warnDuplicates,
+			}
+			err := determineExecutionMode(&FlagConfig{}, tt.args, config)
+
+			w.Close()
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+			devNull.Close()
+			stderrOutput, _ := io.ReadAll(r)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedKeys, config.JIRAIDs)
+			for _, key := range tt.expectedKeys {
+				assert.Equal(t, 1, requests[key], "%s should be fetched once", key)
+			}
+
+			saved, err := evidence.LoadResponseFile(config.OutputFile)
+			require.NoError(t, err)
+			assert.Len(t, saved.Tasks, len(tt.expectedKeys))
+
+			if tt.expectWarning != "" {
+				assert.Contains(t, string(stderrOutput), tt.expectWarning)
+			} else {
+				assert.NotContains(t, string(stderrOutput), "Duplicate JIRA IDs")
+			}
+		})
+	}
+}
+
+func TestDetermineExecutionModeScanFile(t *testing.T) {
+	var mu sync.Mutex
+	requests := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.URL.Path, "/rest/api/2/issue/")
+		mu.Lock()
+		requests[key]++
+		mu.Unlock()
+		fmt.Fprintf(w, `{"key":%q,"fields":{"status":{"name":"Done"}}}`, key)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	scanFile := filepath.Join(dir, "pr_body.md")
+	require.NoError(t, os.WriteFile(scanFile, []byte("## Summary\n\nFixes EV-10 and EV-2.\n\nFollow-up of EV-10, see also OPS-7.\n"), 0644))
+
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	config := &AppConfig{
+		JIRAURL:      server.URL,
+		JIRAToken:    "token",
+		JIRAUsername: "user@example.com",
+		JIRAIDRegex:  DefaultJIRAIDRegex,
+		IgnoreKeys:   []string{"OPS"},
+		OutputFile:   filepath.Join(dir, "evidence.json"),
+		ScanFile:     scanFile,
+	}
+	require.NoError(t, determineExecutionMode(&FlagConfig{}, nil, config))
+
+	assert.Equal(t, []string{"EV-2", "EV-10"}, config.JIRAIDs)
+	assert.Equal(t, map[string]int{"EV-2": 1, "EV-10": 1}, requests)
+	saved, err := evidence.LoadResponseFile(config.OutputFile)
+	require.NoError(t, err)
+	assert.Len(t, saved.Tasks, 2)
+
+	config.ScanFile = filepath.Join(dir, "missing.md")
+	assert.ErrorContains(t, determineExecutionMode(&FlagConfig{}, nil, config), "failed to read scan file")
+}
+
+func TestAttachCommitRefs(t *testing.T) {
+	commit := evidence.CommitRef{Hash: "abc123", Author: "Jane Doe", AuthorEmail: "jane@example.com", Date: "2024-01-15T09:00:00+01:00"}
+	response := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1"},
+			{Key: "NEW-7", ReferencedAs: "OLD-7"},
+			{Key: "EV-9"},
+			{Key: "NEW-5", RequestedKey: "OLD-5"},
+		},
+	}
+
+	attached := attachCommitRefs(response, map[string][]evidence.CommitRef{
+		"EV-1":  {commit},
+		"OLD-7": {commit},
+		"OLD-5": {commit},
+	})
+
+	assert.Equal(t, []evidence.CommitRef{commit}, attached.Tasks[0].Commits)
+	assert.Equal(t, []evidence.CommitRef{commit}, attached.Tasks[1].Commits, "aliased keys match the ID the commit used")
+	assert.Nil(t, attached.Tasks[2].Commits)
+	assert.Equal(t, []evidence.CommitRef{commit}, attached.Tasks[3].Commits, "moved tickets match the ID the commit used")
+	assert.Nil(t, response.Tasks[0].Commits, "the input is left untouched")
+}
+
+func TestCommitRangeForConfig(t *testing.T) {
+	git := evidence.NewGitServiceWithCommand(func(args ...string) (string, error) {
+		switch strings.Join(args, " ") {
+		case "rev-parse --verify main^{commit}", "rev-parse --verify HEAD^{commit}":
+			return "abc123", nil
+		case "merge-base main HEAD":
+			return "def456", nil
+		}
+		return "", fmt.Errorf("unexpected git command: %v", args)
+	}, evidence.ExtractOptions{})
+
+	tests := []struct {
+		name          string
+		config        *AppConfig
+		expectedRange string
+		expectError   bool
+	}{
+		{"Start commit", &AppConfig{StartCommit: "abc123"}, "abc123..HEAD", false},
+		{"From and to refs", &AppConfig{FromRef: "v1.0", ToRef: "v1.1"}, "v1.0..v1.1", false},
+		{"Base branch", &AppConfig{BaseBranch: "main"}, "def456..HEAD", false},
+		{"Unknown base branch", &AppConfig{BaseBranch: "develop"}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			revisionRange, err := commitRangeForConfig(git, tt.config)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRange, revisionRange)
+		})
+	}
+}
+
+func TestOutputUpToDate(t *testing.T) {
+	commitTime := time.Date(2024, 5, 1, 9, 30, 0, 0, time.UTC)
+	git := evidence.NewGitServiceWithCommand(func(args ...string) (string, error) {
+		if strings.Join(args, " ") == "log -1 --format=%ct HEAD" {
+			return strconv.FormatInt(commitTime.Unix(), 10), nil
+		}
+		return "", fmt.Errorf("unexpected git command: %v", args)
+	}, evidence.ExtractOptions{})
+
+	dir := t.TempDir()
+	outputFile := filepath.Join(dir, "transition-check.json")
+
+	upToDate, err := outputUpToDate(git, outputFile)
+	require.NoError(t, err)
+	assert.False(t, upToDate, "a missing file is stale")
+
+	require.NoError(t, os.WriteFile(outputFile, []byte("{}"), 0644))
+	tests := []struct {
+		name     string
+		modTime  time.Time
+		expected bool
+	}{
+		{"Written after the commit", commitTime.Add(time.Minute), true},
+		{"Written before the commit", commitTime.Add(-time.Minute), false},
+		{"Written at the commit time", commitTime, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, os.Chtimes(outputFile, tt.modTime, tt.modTime))
+			upToDate, err := outputUpToDate(git, outputFile)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, upToDate)
+		})
+	}
+
+	failing := evidence.NewGitServiceWithCommand(func(args ...string) (string, error) {
+		return "", errors.New("fatal: bad revision 'HEAD'")
+	}, evidence.ExtractOptions{})
+	_, err = outputUpToDate(failing, outputFile)
+	assert.Error(t, err)
+}
+
+func TestExtractJiraIDsForConfigBaseBranch(t *testing.T) {
+	git := evidence.NewGitServiceWithCommand(func(args ...string) (string, error) {
+		switch strings.Join(args, " ") {
+		case "rev-parse --verify main^{commit}", "rev-parse --verify HEAD^{commit}":
+			return "abc123", nil
+		case "merge-base main HEAD":
+			return "def456", nil
+		case "rev-parse --verify def456", "merge-base --is-ancestor def456 HEAD":
+			return "def456", nil
+		case "log --pretty=format:%s def456..HEAD":
+			return "EV-2: Branch work\nEV-3: More branch work", nil
+		}
+		return "", fmt.Errorf("unexpected git command: %v", args)
+	}, evidence.ExtractOptions{})
+
+	// Silence the merge-base banner
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	ids, err := extractJiraIDsForConfig(git, &AppConfig{BaseBranch: "main", JIRAIDRegex: DefaultJIRAIDRegex}, "")
+
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"EV-2", "EV-3"}, ids)
+
+	_, err = extractJiraIDsForConfig(git, &AppConfig{BaseBranch: "develop", JIRAIDRegex: DefaultJIRAIDRegex}, "")
+	assert.Error(t, err)
+}
+
+func TestExtractJiraIDsForConfigFallbackToRange(t *testing.T) {
+	git := evidence.NewGitServiceWithCommand(func(args ...string) (string, error) {
+		switch strings.Join(args, " ") {
+		case "rev-parse --verify abc123", "merge-base --is-ancestor abc123 HEAD":
+			return "abc123", nil
+		case "log -1 --pretty=format:%s abc123":
+			return "Bump dependencies", nil
+		case "log --pretty=format:%s abc123..HEAD":
+			return "EV-4: Follow-up\nEV-5: Release notes", nil
+		}
+		return "", fmt.Errorf("unexpected git command: %v", args)
+	}, evidence.ExtractOptions{})
+
+	// Silence the fallback notice and the no-IDs warning
+	oldStdout, oldStderr := os.Stdout, os.Stderr
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout, os.Stderr = devNull, devNull
+	defer func() {
+		os.Stdout, os.Stderr = oldStdout, oldStderr
+		devNull.Close()
+	}()
+
+	tests := []struct {
+		name            string
+		fallbackToRange bool
+		expectedIDs     []string
+	}{
+		{name: "No fallback by default", fallbackToRange: false, expectedIDs: nil},
+		{name: "Falls back to the range", fallbackToRange: true, expectedIDs: []string{"EV-4", "EV-5"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := extractJiraIDsForConfig(git, &AppConfig{
+				StartCommit:     "abc123",
+				SingleCommit:    true,
+				FallbackToRange: tt.fallbackToRange,
+				JIRAIDRegex:     DefaultJIRAIDRegex,
+			}, "")
+
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.expectedIDs, ids)
+		})
+	}
+}
+
 func TestAllArgsMatchPattern(t *testing.T) {
 	regex, _ := regexp.Compile("[A-Z]+-[0-9]+")
 
@@ -665,6 +1598,110 @@ func TestDetermineExecutionMode(t *testing.T) {
 	}
 }
 
+func TestDetermineExecutionModeDefaultHead(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Git not installed, skipping real repository test")
+	}
+
+	repoDir := t.TempDir()
+	runGit := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "HOME="+repoDir, "GIT_CONFIG_NOSYSTEM=1")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+	runGit("init", "-q")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test")
+	runGit("commit", "-q", "--allow-empty", "-m", "EV-1: Initial commit")
+	runGit("commit", "-q", "--allow-empty", "-m", "EV-2: Latest commit")
+	head := runGit("rev-parse", "HEAD")
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(repoDir))
+	defer os.Chdir(oldDir)
+	t.Setenv("HOME", repoDir)
+
+	oldStdout := os.Stdout
+	r, w, _ := os.Pipe()
+	os.Stdout = w
+
+	config := &AppConfig{JIRAIDRegex: DefaultJIRAIDRegex, ExtractOnly: true, SingleCommit: true, DefaultHead: true}
+	err = determineExecutionMode(&FlagConfig{ExtractOnly: true}, []string{}, config)
+
+	w.Close()
+	os.Stdout = oldStdout
+	output, _ := io.ReadAll(r)
+
+	require.NoError(t, err)
+	assert.Equal(t, head, config.StartCommit)
+	assert.Contains(t, string(output), "No commit given, using HEAD ("+head+")")
+	assert.Contains(t, string(output), "\nEV-2\n")
+	assert.NotContains(t, string(output), "EV-1")
+}
+
+func TestRunExtractOnlyModeBranchRule(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("Git not installed, skipping real repository test")
+	}
+
+	repoDir := t.TempDir()
+	runGit := func(args ...string) string {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = repoDir
+		cmd.Env = append(os.Environ(), "HOME="+repoDir, "GIT_CONFIG_NOSYSTEM=1")
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+		return strings.TrimSpace(string(output))
+	}
+	runGit("init", "-q", "-b", "main")
+	runGit("config", "user.email", "test@example.com")
+	runGit("config", "user.name", "Test")
+	runGit("commit", "-q", "--allow-empty", "-m", "EV-1: Initial commit")
+	runGit("checkout", "-q", "-b", "hotfix/login")
+	runGit("commit", "-q", "--allow-empty", "-m", "SUP-7: Fix login for EV-2")
+	head := runGit("rev-parse", "HEAD")
+
+	oldDir, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(repoDir))
+	defer os.Chdir(oldDir)
+	t.Setenv("HOME", repoDir)
+
+	config := &AppConfig{
+		JIRAIDRegex:  "EV-[0-9]+",
+		StartCommit:  head,
+		SingleCommit: true,
+		ExtractOnly:  true,
+		BranchRules:  []BranchRule{{Branch: "hotfix/*", JIRAIDRegex: "SUP-[0-9]+"}},
+	}
+	run := func() string {
+		oldStdout := os.Stdout
+		r, w, _ := os.Pipe()
+		os.Stdout = w
+		err := runExtractOnlyMode(config)
+		w.Close()
+		os.Stdout = oldStdout
+		output, _ := io.ReadAll(r)
+		require.NoError(t, err)
+		return string(output)
+	}
+
+	output := run()
+	assert.Contains(t, output, "Branch Rule: hotfix/*\nJIRA ID Regex: SUP-[0-9]+\n")
+	assert.Contains(t, output, "\nSUP-7\n")
+	assert.Equal(t, "EV-[0-9]+", config.JIRAIDRegex, "the rule only applies to the run")
+
+	// On a branch without a rule the top-level regex applies
+	runGit("checkout", "-q", "-b", "feature/login")
+	output = run()
+	assert.NotContains(t, output, "Branch Rule")
+	assert.Contains(t, output, "\nEV-2\n")
+}
+
 // TestRunFullMode tests are covered indirectly through TestDetermineExecutionMode
 // The runFullMode function orchestrates Git operations and JIRA API calls which
 // are all tested individually in their respective test files.
@@ -791,6 +1828,52 @@ func TestRunMarkdownMode(t *testing.T) {
 			expectError: false,
 			expectFiles: []string{"env_output.md"},
 		},
+		{
+			name: "Generate one markdown file per ticket",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				OutputFile:       "split_input.json",
+				SplitOutput:      "wiki",
+			},
+			setupFiles: map[string]string{
+				"split_input.json": `{"tasks": [{"key": "SPLIT-1", "status": "Done"}, {"key": "SPLIT-2", "status": "Done"}]}`,
+			},
+			envVars:     map[string]string{},
+			expectError: false,
+			expectFiles: []string{"wiki/index.md", "wiki/SPLIT-1.md", "wiki/SPLIT-2.md"},
+		},
+		{
+			name: "Environment references in the input and output files are expanded",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				OutputFile:       "$MDDIR/in.json",
+				MarkdownOutput:   "${MDDIR}/out.md",
+			},
+			setupFiles: map[string]string{
+				"mddir/in.json": `{"tasks": [{"key": "ENV-1", "status": "Done"}]}`,
+			},
+			envVars: map[string]string{
+				"MDDIR": "mddir",
+			},
+			expectError: false,
+			expectFiles: []string{"mddir/out.md"},
+		},
+		{
+			name: "Environment references in the split output directory are expanded",
+			flags: &FlagConfig{
+				GenerateMarkdown: true,
+				OutputFile:       "$MDDIR/in.json",
+				SplitOutput:      "$MDDIR/wiki",
+			},
+			setupFiles: map[string]string{
+				"mddir/in.json": `{"tasks": [{"key": "ENV-2", "status": "Done"}]}`,
+			},
+			envVars: map[string]string{
+				"MDDIR": "mddir",
+			},
+			expectError: false,
+			expectFiles: []string{"mddir/wiki/index.md", "mddir/wiki/ENV-2.md"},
+		},
 		{
 			name: "Error when input file doesn't exist",
 			flags: &FlagConfig{
@@ -822,17 +1905,22 @@ func TestRunMarkdownMode(t *testing.T) {
 
 			// Create test files
 			for filename, content := range tt.setupFiles {
+				assert.NoError(t, os.MkdirAll(filepath.Dir(filename), 0755))
 				err := os.WriteFile(filename, []byte(content), 0644)
 				assert.NoError(t, err)
 			}
 
+			// Run with the configuration main would build
+			config, err := LoadConfig(tt.flags, []string{})
+			require.NoError(t, err)
+
 			// Capture stdout
 			oldStdout := os.Stdout
 			r, w, _ := os.Pipe()
 			os.Stdout = w
 
 			// Run the function
-			err := runMarkdownMode(tt.flags)
+			err = runMarkdownMode(config)
 
 			// Restore stdout
 			w.Close()
@@ -949,3 +2037,61 @@ func TestDetermineExecutionModeWithMarkdown(t *testing.T) {
 		})
 	}
 }
+
+func TestRunMergeMode(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "merge-mode-test")
+	assert.NoError(t, err)
+	defer os.RemoveAll(tempDir)
+
+	fileA := filepath.Join(tempDir, "a.json")
+	fileB := filepath.Join(tempDir, "b.json")
+	assert.NoError(t, os.WriteFile(fileA, []byte(`{"tasks": [{"key": "EV-1", "status": "Done"}]}`), 0644))
+	assert.NoError(t, os.WriteFile(fileB, []byte(`{"tasks": [{"key": "EV-2", "status": "Open"}]}`), 0644))
+
+	tests := []struct {
+		name        string
+		args        []string
+		expectError bool
+		errorMsg    string
+	}{
+		{
+			name:        "Merges two files",
+			args:        []string{fileA, fileB},
+			expectError: false,
+		},
+		{
+			name:        "Requires at least two files",
+			args:        []string{fileA},
+			expectError: true,
+			errorMsg:    "at least two input files",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &AppConfig{OutputFile: filepath.Join(tempDir, "combined.json")}
+
+			// Capture stdout
+			oldStdout := os.Stdout
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+
+			err := determineExecutionMode(&FlagConfig{Merge: true}, tt.args, config)
+
+			w.Close()
+			os.Stdout = oldStdout
+			buf := make([]byte, 4096)
+			n, _ := r.Read(buf)
+			output := string(buf[:n])
+
+			if tt.expectError {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errorMsg)
+			} else {
+				assert.NoError(t, err)
+				assert.Contains(t, output, "Evidence Merge Mode")
+				assert.Contains(t, output, "Merged 2 tasks")
+			}
+		})
+	}
+}
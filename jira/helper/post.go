@@ -0,0 +1,45 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"jira-helper/evidence"
+)
+
+// postTimeout bounds the --post-url request so an unresponsive intake doesn't hang the build
+const postTimeout = 30 * time.Second
+
+// maxPostErrorBody is how much of a failed response's body is quoted in the error
+const maxPostErrorBody = 4096
+
+// postResults POSTs the evidence JSON to --post-url, with the --post-auth value as the
+// Authorization header when set. A non-2xx answer is an error quoting the response body.
+func postResults(config *AppConfig, jsonBytes []byte) error {
+	req, err := http.NewRequest(http.MethodPost, config.PostURL, bytes.NewReader(jsonBytes))
+	if err != nil {
+		return fmt.Errorf("error creating POST request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", getOrDefault(config.UserAgent, evidence.DefaultUserAgent(version)))
+	if config.PostAuth != "" {
+		req.Header.Set("Authorization", config.PostAuth)
+	}
+
+	client := &http.Client{Timeout: postTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting JIRA data to %s: %v", config.PostURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxPostErrorBody))
+		return fmt.Errorf("posting JIRA data to %s failed with status %s: %s", config.PostURL, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"jira-helper/evidence"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPostResults(t *testing.T) {
+	tests := []struct {
+		name        string
+		postAuth    string
+		status      int
+		body        string
+		expectError string
+	}{
+		{
+			name:     "Accepted with auth header",
+			postAuth: "Bearer secret",
+			status:   http.StatusCreated,
+		},
+		{
+			name:   "Accepted without auth header",
+			status: http.StatusOK,
+		},
+		{
+			name:        "Non-2xx response quotes the body",
+			status:      http.StatusUnauthorized,
+			body:        "invalid token\n",
+			expectError: "failed with status 401 Unauthorized: invalid token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotBody []byte
+			var gotHeaders http.Header
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				gotHeaders = r.Header.Clone()
+				gotBody, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			err := postResults(&AppConfig{PostURL: server.URL, PostAuth: tt.postAuth}, []byte(`{"tasks":[]}`))
+			if tt.expectError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.expectError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, `{"tasks":[]}`, string(gotBody))
+			assert.Equal(t, "application/json", gotHeaders.Get("Content-Type"))
+			assert.Equal(t, tt.postAuth, gotHeaders.Get("Authorization"))
+			assert.Equal(t, evidence.DefaultUserAgent(version), gotHeaders.Get("User-Agent"))
+		})
+	}
+}
+
+func TestSaveJiraResultsPost(t *testing.T) {
+	response := evidence.TransitionCheckResponse{
+		Tasks: []evidence.JiraTransitionResult{
+			{Key: "EV-1", Status: "Done", Type: "Task"},
+		},
+	}
+
+	oldStdout := os.Stdout
+	devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	os.Stdout = devNull
+	defer func() {
+		os.Stdout = oldStdout
+		devNull.Close()
+	}()
+
+	t.Run("Posts JSON even when only markdown is written", func(t *testing.T) {
+		var posted []byte
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			posted, _ = io.ReadAll(r.Body)
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		outputFile := filepath.Join(t.TempDir(), "evidence.json")
+		require.NoError(t, saveJiraResults(response, &AppConfig{OutputFile: outputFile, Formats: []string{FormatMarkdown}, PostURL: server.URL}, false))
+
+		assert.Contains(t, string(posted), `"key": "EV-1"`)
+		_, err := os.Stat(outputFile)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("Rejected POST fails the run", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "intake unavailable", http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		outputFile := filepath.Join(t.TempDir(), "evidence.json")
+		err := saveJiraResults(response, &AppConfig{OutputFile: outputFile, Formats: []string{FormatJSON}, PostURL: server.URL}, false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "intake unavailable")
+	})
+}
@@ -2,19 +2,55 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"os"
-	"path/filepath"
+	"strings"
+
+	"jira-helper/evidence"
 )
 
-// writeToFile writes data to a file
+// writeToFile writes data to a file. The write is atomic: readers see either the previous file or
+// the complete new one, never a truncated file left behind by a killed or failed run.
 func writeToFile(filename string, data []byte) error {
-	// Create directory if it doesn't exist
-	dir := filepath.Dir(filename)
-	if dir != "." {
-		if err := os.MkdirAll(dir, 0755); err != nil {
-			return fmt.Errorf("failed to create directory: %v", err)
+	return evidence.WriteFileAtomic(filename, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// readCommitsFile reads the commit hashes listed in a --commits-file, one per line.
+// Blank lines and lines starting with # are skipped.
+func readCommitsFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read commits file: %w", err)
+	}
+
+	var commits []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
 		}
+		commits = append(commits, line)
 	}
+	return commits, nil
+}
 
-	return os.WriteFile(filename, data, 0644)
+// dedupeJiraIDs returns the IDs without repeats, keeping the first occurrence of each in order,
+// and the IDs that were repeated (each once, in order of first occurrence)
+func dedupeJiraIDs(jiraIDs []string) ([]string, []string) {
+	counts := make(map[string]int, len(jiraIDs))
+	unique := make([]string, 0, len(jiraIDs))
+	var duplicates []string
+	for _, jiraID := range jiraIDs {
+		counts[jiraID]++
+		switch counts[jiraID] {
+		case 1:
+			unique = append(unique, jiraID)
+		case 2:
+			duplicates = append(duplicates, jiraID)
+		}
+	}
+	return unique, duplicates
 }
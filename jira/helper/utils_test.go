@@ -8,6 +8,18 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestReadCommitsFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "approved.txt")
+	assert.NoError(t, os.WriteFile(path, []byte("# approved for release 1.2\naaa111\n\n  bbb222  \r\n# ccc333\n"), 0644))
+
+	commits, err := readCommitsFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"aaa111", "bbb222"}, commits)
+
+	_, err = readCommitsFile(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(t, err)
+}
+
 func TestWriteToFile(t *testing.T) {
 	// Create a temporary directory for testing
 	tempDir, err := os.MkdirTemp("", "jira-helper-test")
@@ -131,3 +143,25 @@ func TestWriteToFile_DirectoryCreation(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, data, content)
 }
+
+func TestDedupeJiraIDs(t *testing.T) {
+	tests := []struct {
+		name               string
+		jiraIDs            []string
+		expectedUnique     []string
+		expectedDuplicates []string
+	}{
+		{"No IDs", nil, []string{}, nil},
+		{"No repeats", []string{"EV-1", "EV-2"}, []string{"EV-1", "EV-2"}, nil},
+		{"Repeats keep the first position", []string{"EV-2", "EV-1", "EV-2", "EV-2", "EV-1"}, []string{"EV-2", "EV-1"}, []string{"EV-2", "EV-1"}},
+		{"Case is significant", []string{"ev-1", "EV-1"}, []string{"ev-1", "EV-1"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			unique, duplicates := dedupeJiraIDs(tt.jiraIDs)
+			assert.Equal(t, tt.expectedUnique, unique)
+			assert.Equal(t, tt.expectedDuplicates, duplicates)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"jira-helper/evidence"
+)
+
+// runWatchMode runs the pipeline once and then again every time HEAD changes, until interrupted
+func runWatchMode(git *evidence.GitService, config *AppConfig, run func() error) error {
+	interval := config.WatchInterval
+	if interval == 0 {
+		interval = DefaultWatchPeriod
+	}
+
+	stop := make(chan struct{})
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		<-signals
+		close(stop)
+	}()
+
+	fmt.Printf("=== Watch Mode (polling HEAD every %s, press Ctrl-C to stop) ===\n", interval)
+	err := watchHead(git, interval, stop, run)
+	fmt.Println("")
+	fmt.Println("=== Watch mode stopped ===")
+	return err
+}
+
+// watchHead runs the pipeline immediately and re-runs it whenever the HEAD commit changes.
+// A change is only acted upon once HEAD has stayed the same for a full interval, so rapid
+// successive commits (e.g. during a rebase) trigger a single run.
+// Pipeline errors are reported but don't stop watching; git errors do. A run interrupted by
+// SIGINT/SIGTERM stops watching with ErrInterrupted, so the partial output is reported as such.
+func watchHead(git *evidence.GitService, interval time.Duration, stop <-chan struct{}, run func() error) error {
+	lastRun, err := git.GetHeadCommit()
+	if err != nil {
+		return err
+	}
+	if err := runWithTimestamp(lastRun, run); errors.Is(err, ErrInterrupted) {
+		return err
+	}
+
+	pending := ""
+	for {
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(interval):
+		}
+
+		head, err := git.GetHeadCommit()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case head == lastRun:
+			// Nothing new (or a change was reverted before it settled)
+			pending = ""
+		case head != pending:
+			// HEAD moved; wait one more interval to see if it settles
+			pending = head
+		default:
+			// HEAD has been stable for a full interval
+			lastRun = head
+			pending = ""
+			if err := runWithTimestamp(head, run); errors.Is(err, ErrInterrupted) {
+				return err
+			}
+		}
+	}
+}
+
+// runWithTimestamp runs the pipeline for a HEAD commit, printing when the run started, and
+// returns its error. Errors are printed here, except an interruption, which main reports.
+func runWithTimestamp(head string, run func() error) error {
+	fmt.Println("")
+	fmt.Printf("--- [%s] Running for HEAD %s ---\n", time.Now().Format("2006-01-02 15:04:05"), head)
+	err := run()
+	if err != nil && !errors.Is(err, ErrInterrupted) {
+		evidence.PrintError("Error: %v", err)
+	}
+	return err
+}
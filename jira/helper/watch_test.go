@@ -0,0 +1,152 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"jira-helper/evidence"
+)
+
+// createSequenceGitCommand returns successive HEAD hashes, repeating the last one.
+// Once every scripted hash has been returned (plus one settling poll), stop is closed.
+func createSequenceGitCommand(heads []string, stop chan struct{}) func(args ...string) (string, error) {
+	calls := 0
+	return func(args ...string) (string, error) {
+		head := heads[len(heads)-1]
+		if calls < len(heads) {
+			head = heads[calls]
+		}
+		calls++
+		if calls == len(heads)+1 {
+			close(stop)
+		}
+		return head, nil
+	}
+}
+
+func TestWatchHead(t *testing.T) {
+	tests := []struct {
+		name         string
+		heads        []string
+		expectedRuns int
+	}{
+		{
+			name:         "Runs once when HEAD never changes",
+			heads:        []string{"aaa"},
+			expectedRuns: 1,
+		},
+		{
+			name:         "Runs again after HEAD changes and settles",
+			heads:        []string{"aaa", "aaa", "bbb", "bbb"},
+			expectedRuns: 2,
+		},
+		{
+			name:         "Rapid changes are debounced into one run",
+			heads:        []string{"aaa", "bbb", "ccc", "ddd", "ddd"},
+			expectedRuns: 2,
+		},
+		{
+			name:         "Change reverted before settling does not run",
+			heads:        []string{"aaa", "bbb", "aaa", "aaa"},
+			expectedRuns: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stop := make(chan struct{})
+			git := evidence.NewGitServiceWithCommand(createSequenceGitCommand(tt.heads, stop), evidence.ExtractOptions{})
+
+			// Silence run banners
+			oldStdout := os.Stdout
+			devNull, _ := os.Open(os.DevNull)
+			os.Stdout = devNull
+			defer func() {
+				os.Stdout = oldStdout
+				devNull.Close()
+			}()
+
+			runs := 0
+			err := watchHead(git, time.Millisecond, stop, func() error {
+				runs++
+				return nil
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedRuns, runs)
+		})
+	}
+}
+
+func TestWatchHeadErrors(t *testing.T) {
+	t.Run("Git failure stops watching", func(t *testing.T) {
+		git := evidence.NewGitServiceWithCommand(func(args ...string) (string, error) {
+			return "", errors.New("not a git repository")
+		}, evidence.ExtractOptions{})
+
+		err := watchHead(git, time.Millisecond, make(chan struct{}), func() error { return nil })
+		assert.Error(t, err)
+	})
+
+	t.Run("Pipeline errors do not stop watching", func(t *testing.T) {
+		stop := make(chan struct{})
+		git := evidence.NewGitServiceWithCommand(createSequenceGitCommand([]string{"aaa", "bbb", "bbb"}, stop), evidence.ExtractOptions{})
+
+		oldStdout, oldStderr := os.Stdout, os.Stderr
+		devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+		os.Stdout, os.Stderr = devNull, devNull
+		defer func() {
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+			devNull.Close()
+		}()
+
+		runs := 0
+		err := watchHead(git, time.Millisecond, stop, func() error {
+			runs++
+			return errors.New("fetch failed")
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, runs)
+	})
+
+	t.Run("Interrupted run stops watching", func(t *testing.T) {
+		tests := []struct {
+			heads         []string
+			interruptedAt int
+		}{
+			{heads: []string{"aaa"}, interruptedAt: 1},
+			{heads: []string{"aaa", "bbb", "bbb", "bbb"}, interruptedAt: 2},
+		}
+
+		for _, tt := range tests {
+			stop := make(chan struct{})
+			git := evidence.NewGitServiceWithCommand(createSequenceGitCommand(tt.heads, stop), evidence.ExtractOptions{})
+
+			oldStdout, oldStderr := os.Stdout, os.Stderr
+			devNull, _ := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+			os.Stdout, os.Stderr = devNull, devNull
+
+			// The signal reaches the run's fetch, which saves partial output and reports it
+			runs := 0
+			err := watchHead(git, time.Millisecond, stop, func() error {
+				runs++
+				if runs == tt.interruptedAt {
+					return fmt.Errorf("fetching: %w", ErrInterrupted)
+				}
+				return nil
+			})
+
+			os.Stdout, os.Stderr = oldStdout, oldStderr
+			devNull.Close()
+
+			assert.ErrorIs(t, err, ErrInterrupted, "main exits with ExitCodeInterrupted")
+			assert.Equal(t, tt.interruptedAt, runs)
+		}
+	})
+}